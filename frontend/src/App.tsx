@@ -52,7 +52,7 @@ declare module "@wailsjs/go/bindings/Music" {
     albumName: string,
   ): Promise<void>;
   export function RemoveTrack(trackId: string): Promise<void>;
-  export function RequestNewSuggestion(): Promise<spotify.SuggestedTrackInfo | null>;
+  export function RequestNewSuggestion(requestContext: session.RequestContext): Promise<spotify.SuggestedTrackInfo | null>;
   export function SaveTrack(trackId: string): Promise<void>;
   export function SearchTracks(
     query: string,
@@ -66,7 +66,7 @@ declare module "@wailsjs/go/bindings/Movies" {
   export function GetInitialMovies(): Promise<Record<string, any>>;
   export function HasValidCredentials(): Promise<boolean>;
   export function RefreshCredentials(): Promise<boolean>;
-  export function GetMovieSuggestion(): Promise<{
+  export function GetMovieSuggestion(requestContext: session.RequestContext): Promise<{
     movie: MovieWithSavedStatus;
     reason: string;
   } | null>;
@@ -84,7 +84,7 @@ export const BookSection = forwardRef<BookSectionHandle, {}>((props, ref) => {
     checkCredentials: async () => true, // No credentials needed for books
 
     getSuggestion: async () => {
-      const suggestion = await GetBookSuggestion();
+      const suggestion = await GetBookSuggestion(new session.RequestContext());
       
       if (!suggestion) {
         throw new Error("Failed to get book suggestion");
@@ -54,7 +54,7 @@ export const GameSection = forwardRef<GameSectionHandle, {}>((props, ref) => {
       return hasCredentials;
     },
     getSuggestion: async () => {
-      const suggestion = await GetGameSuggestion();
+      const suggestion = await GetGameSuggestion(new session.RequestContext());
       if (!suggestion) {
         throw new Error("Failed to get game suggestion");
       }
@@ -58,7 +58,7 @@ export const MovieSection = forwardRef<MovieSectionHandle, {}>((props, ref) => {
       return hasCredentials;
     },
     getSuggestion: async () => {
-      const suggestion = await GetMovieSuggestion();
+      const suggestion = await GetMovieSuggestion(new session.RequestContext());
       if (!suggestion) {
         throw new Error("Failed to get movie suggestion");
       }
@@ -262,7 +262,7 @@ export function SuggestionProvider({
       setIsFetchingSuggestion(true);
       setSuggestionError(null);
       setSuggestionState((prev) => ({ ...prev, isProcessing: true }));
-      const suggestion = await RequestNewSuggestion();
+      const suggestion = await RequestNewSuggestion(new session.RequestContext());
       if (suggestion) {
         console.log(
           "[SuggestionContext] Received new suggestion:",
@@ -338,6 +338,7 @@ export function SuggestionProvider({
         name: suggestedTrack.name,
         artist: suggestedTrack.artist,
         album: suggestedTrack.album,
+        unit: suggestedTrack.unit,
       };
 
       // Mark as processing first
@@ -350,6 +351,7 @@ export function SuggestionProvider({
           trackToSkip.name,
           trackToSkip.artist,
           trackToSkip.album,
+          trackToSkip.unit as session.MusicUnit,
         );
 
         setSuggestionState((prev) => ({
@@ -398,6 +400,7 @@ export function SuggestionProvider({
         suggestedTrack.name,
         suggestedTrack.artist,
         suggestedTrack.album,
+        suggestedTrack.unit as session.MusicUnit,
       );
 
       // Update UI state after successful backend call
@@ -499,6 +502,7 @@ export function SuggestionProvider({
         suggestedTrack.name,
         suggestedTrack.artist,
         suggestedTrack.album,
+        suggestedTrack.unit as session.MusicUnit,
       );
 
       setSuggestionState((prev) => ({
@@ -53,7 +53,7 @@ export const TVShowSection = forwardRef<TVShowSectionHandle, {}>(
         return hasCredentials;
       },
       getSuggestion: async () => {
-        const suggestion = await GetTVShowSuggestion();
+        const suggestion = await GetTVShowSuggestion(new session.RequestContext());
         if (!suggestion) {
           throw new Error("Failed to get TV show suggestion");
         }
@@ -3,18 +3,36 @@
 import {session} from '../models';
 import {bindings} from '../models';
 
+export function AddFavoriteByISBN(arg1:string):Promise<session.Book>;
+
 export function AddToReadList(arg1:session.Book):Promise<void>;
 
+export function AddToReadListByISBN(arg1:string):Promise<session.Book>;
+
 export function GetBookDetails(arg1:string):Promise<bindings.BookWithSavedStatus>;
 
-export function GetBookSuggestion():Promise<Record<string, any>>;
+export function GetBookSuggestion(arg1:session.RequestContext):Promise<Record<string, any>>;
+
+export function GetCrossMediaSuggestion(arg1:Array<session.MediaType>,arg2:session.RequestContext):Promise<Record<string, any>>;
+
+export function GetDiscoverySuggestion(arg1:session.RequestContext):Promise<Record<string, any>>;
+
+export function GetEditions(arg1:string):Promise<Array<bindings.BookEdition>>;
 
 export function GetFavoriteBooks():Promise<Array<session.Book>>;
 
 export function GetReadList():Promise<Array<session.Book>>;
 
+export function GetSubjectProfile():Promise<Array<bindings.SubjectWeight>>;
+
+export function GetTasteProfile():Promise<session.TasteProfile>;
+
+export function LookupISBN(arg1:string):Promise<bindings.BookEdition>;
+
 export function ProvideSuggestionFeedback(arg1:session.Outcome,arg2:string,arg3:string):Promise<void>;
 
+export function RefineSuggestion(arg1:string):Promise<Record<string, any>>;
+
 export function RefreshLLMClients():Promise<void>;
 
 export function RemoveFromReadList(arg1:string,arg2:string):Promise<void>;
@@ -22,3 +40,5 @@ export function RemoveFromReadList(arg1:string,arg2:string):Promise<void>;
 export function SearchBooks(arg1:string):Promise<Array<bindings.BookWithSavedStatus>>;
 
 export function SetFavoriteBooks(arg1:Array<session.Book>):Promise<void>;
+
+export function UpdateTasteProfile(arg1:string):Promise<session.TasteProfile>;
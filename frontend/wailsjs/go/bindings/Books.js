@@ -2,16 +2,36 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddFavoriteByISBN(arg1) {
+  return window['go']['bindings']['Books']['AddFavoriteByISBN'](arg1);
+}
+
 export function AddToReadList(arg1) {
   return window['go']['bindings']['Books']['AddToReadList'](arg1);
 }
 
+export function AddToReadListByISBN(arg1) {
+  return window['go']['bindings']['Books']['AddToReadListByISBN'](arg1);
+}
+
 export function GetBookDetails(arg1) {
   return window['go']['bindings']['Books']['GetBookDetails'](arg1);
 }
 
-export function GetBookSuggestion() {
-  return window['go']['bindings']['Books']['GetBookSuggestion']();
+export function GetBookSuggestion(arg1) {
+  return window['go']['bindings']['Books']['GetBookSuggestion'](arg1);
+}
+
+export function GetCrossMediaSuggestion(arg1, arg2) {
+  return window['go']['bindings']['Books']['GetCrossMediaSuggestion'](arg1, arg2);
+}
+
+export function GetDiscoverySuggestion(arg1) {
+  return window['go']['bindings']['Books']['GetDiscoverySuggestion'](arg1);
+}
+
+export function GetEditions(arg1) {
+  return window['go']['bindings']['Books']['GetEditions'](arg1);
 }
 
 export function GetFavoriteBooks() {
@@ -22,10 +42,26 @@ export function GetReadList() {
   return window['go']['bindings']['Books']['GetReadList']();
 }
 
+export function GetSubjectProfile() {
+  return window['go']['bindings']['Books']['GetSubjectProfile']();
+}
+
+export function GetTasteProfile() {
+  return window['go']['bindings']['Books']['GetTasteProfile']();
+}
+
+export function LookupISBN(arg1) {
+  return window['go']['bindings']['Books']['LookupISBN'](arg1);
+}
+
 export function ProvideSuggestionFeedback(arg1, arg2, arg3) {
   return window['go']['bindings']['Books']['ProvideSuggestionFeedback'](arg1, arg2, arg3);
 }
 
+export function RefineSuggestion(arg1) {
+  return window['go']['bindings']['Books']['RefineSuggestion'](arg1);
+}
+
 export function RefreshLLMClients() {
   return window['go']['bindings']['Books']['RefreshLLMClients']();
 }
@@ -41,3 +77,7 @@ export function SearchBooks(arg1) {
 export function SetFavoriteBooks(arg1) {
   return window['go']['bindings']['Books']['SetFavoriteBooks'](arg1);
 }
+
+export function UpdateTasteProfile(arg1) {
+  return window['go']['bindings']['Books']['UpdateTasteProfile'](arg1);
+}
@@ -5,11 +5,21 @@ import {bindings} from '../models';
 
 export function AddToWatchlist(arg1:session.VideoGame):Promise<void>;
 
+export function GetAvailablePlatforms():Promise<Array<session.GamePlatform>>;
+
+export function GetBacklog():Promise<Array<session.BacklogEntry>>;
+
+export function GetCrossMediaSuggestion(arg1:Array<session.MediaType>,arg2:session.RequestContext):Promise<Record<string, any>>;
+
 export function GetFavoriteGames():Promise<Array<session.VideoGame>>;
 
 export function GetGameDetails(arg1:number):Promise<bindings.GameWithSavedStatus>;
 
-export function GetGameSuggestion():Promise<Record<string, any>>;
+export function GetGameSuggestion(arg1:session.RequestContext):Promise<Record<string, any>>;
+
+export function GetPlayNext(arg1:number):Promise<Array<bindings.PlayNextPick>>;
+
+export function GetTasteProfile():Promise<session.TasteProfile>;
 
 export function GetWatchlist():Promise<Array<session.VideoGame>>;
 
@@ -17,6 +27,8 @@ export function HasValidCredentials():Promise<boolean>;
 
 export function ProvideSuggestionFeedback(arg1:session.Outcome,arg2:number):Promise<void>;
 
+export function RefineSuggestion(arg1:string):Promise<Record<string, any>>;
+
 export function RefreshCredentials():Promise<boolean>;
 
 export function RefreshLLMClients():Promise<void>;
@@ -26,3 +38,7 @@ export function RemoveFromWatchlist(arg1:string):Promise<void>;
 export function SearchGames(arg1:string):Promise<Array<bindings.GameWithSavedStatus>>;
 
 export function SetFavoriteGames(arg1:Array<session.VideoGame>):Promise<void>;
+
+export function UpdateBacklogEntry(arg1:string,arg2:session.BacklogStatus,arg3:number,arg4:number):Promise<session.BacklogEntry>;
+
+export function UpdateTasteProfile(arg1:string):Promise<session.TasteProfile>;
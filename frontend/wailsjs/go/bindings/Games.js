@@ -6,6 +6,18 @@ export function AddToWatchlist(arg1) {
   return window['go']['bindings']['Games']['AddToWatchlist'](arg1);
 }
 
+export function GetAvailablePlatforms() {
+  return window['go']['bindings']['Games']['GetAvailablePlatforms']();
+}
+
+export function GetBacklog() {
+  return window['go']['bindings']['Games']['GetBacklog']();
+}
+
+export function GetCrossMediaSuggestion(arg1, arg2) {
+  return window['go']['bindings']['Games']['GetCrossMediaSuggestion'](arg1, arg2);
+}
+
 export function GetFavoriteGames() {
   return window['go']['bindings']['Games']['GetFavoriteGames']();
 }
@@ -14,8 +26,16 @@ export function GetGameDetails(arg1) {
   return window['go']['bindings']['Games']['GetGameDetails'](arg1);
 }
 
-export function GetGameSuggestion() {
-  return window['go']['bindings']['Games']['GetGameSuggestion']();
+export function GetGameSuggestion(arg1) {
+  return window['go']['bindings']['Games']['GetGameSuggestion'](arg1);
+}
+
+export function GetPlayNext(arg1) {
+  return window['go']['bindings']['Games']['GetPlayNext'](arg1);
+}
+
+export function GetTasteProfile() {
+  return window['go']['bindings']['Games']['GetTasteProfile']();
 }
 
 export function GetWatchlist() {
@@ -30,6 +50,10 @@ export function ProvideSuggestionFeedback(arg1, arg2) {
   return window['go']['bindings']['Games']['ProvideSuggestionFeedback'](arg1, arg2);
 }
 
+export function RefineSuggestion(arg1) {
+  return window['go']['bindings']['Games']['RefineSuggestion'](arg1);
+}
+
 export function RefreshCredentials() {
   return window['go']['bindings']['Games']['RefreshCredentials']();
 }
@@ -49,3 +73,11 @@ export function SearchGames(arg1) {
 export function SetFavoriteGames(arg1) {
   return window['go']['bindings']['Games']['SetFavoriteGames'](arg1);
 }
+
+export function UpdateBacklogEntry(arg1, arg2, arg3, arg4) {
+  return window['go']['bindings']['Games']['UpdateBacklogEntry'](arg1, arg2, arg3, arg4);
+}
+
+export function UpdateTasteProfile(arg1) {
+  return window['go']['bindings']['Games']['UpdateTasteProfile'](arg1);
+}
@@ -5,11 +5,15 @@ import {bindings} from '../models';
 
 export function AddToWatchlist(arg1:session.Movie):Promise<void>;
 
+export function GetCrossMediaSuggestion(arg1:Array<session.MediaType>,arg2:session.RequestContext):Promise<Record<string, any>>;
+
 export function GetFavoriteMovies():Promise<Array<session.Movie>>;
 
 export function GetMovieDetails(arg1:number):Promise<bindings.MovieWithSavedStatus>;
 
-export function GetMovieSuggestion():Promise<Record<string, any>>;
+export function GetMovieSuggestion(arg1:session.RequestContext):Promise<Record<string, any>>;
+
+export function GetTasteProfile():Promise<session.TasteProfile>;
 
 export function GetWatchlist():Promise<Array<session.Movie>>;
 
@@ -17,6 +21,8 @@ export function HasValidCredentials():Promise<boolean>;
 
 export function ProvideSuggestionFeedback(arg1:session.Outcome,arg2:number):Promise<void>;
 
+export function RefineSuggestion(arg1:string):Promise<Record<string, any>>;
+
 export function RefreshCredentials():Promise<boolean>;
 
 export function RefreshLLMClients():Promise<void>;
@@ -26,3 +32,5 @@ export function RemoveFromWatchlist(arg1:string):Promise<void>;
 export function SearchMovies(arg1:string):Promise<Array<bindings.MovieWithSavedStatus>>;
 
 export function SetFavoriteMovies(arg1:Array<session.Movie>):Promise<void>;
+
+export function UpdateTasteProfile(arg1:string):Promise<session.TasteProfile>;
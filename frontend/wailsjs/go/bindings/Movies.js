@@ -6,6 +6,10 @@ export function AddToWatchlist(arg1) {
   return window['go']['bindings']['Movies']['AddToWatchlist'](arg1);
 }
 
+export function GetCrossMediaSuggestion(arg1, arg2) {
+  return window['go']['bindings']['Movies']['GetCrossMediaSuggestion'](arg1, arg2);
+}
+
 export function GetFavoriteMovies() {
   return window['go']['bindings']['Movies']['GetFavoriteMovies']();
 }
@@ -14,8 +18,12 @@ export function GetMovieDetails(arg1) {
   return window['go']['bindings']['Movies']['GetMovieDetails'](arg1);
 }
 
-export function GetMovieSuggestion() {
-  return window['go']['bindings']['Movies']['GetMovieSuggestion']();
+export function GetMovieSuggestion(arg1) {
+  return window['go']['bindings']['Movies']['GetMovieSuggestion'](arg1);
+}
+
+export function GetTasteProfile() {
+  return window['go']['bindings']['Movies']['GetTasteProfile']();
 }
 
 export function GetWatchlist() {
@@ -30,6 +38,10 @@ export function ProvideSuggestionFeedback(arg1, arg2) {
   return window['go']['bindings']['Movies']['ProvideSuggestionFeedback'](arg1, arg2);
 }
 
+export function RefineSuggestion(arg1) {
+  return window['go']['bindings']['Movies']['RefineSuggestion'](arg1);
+}
+
 export function RefreshCredentials() {
   return window['go']['bindings']['Movies']['RefreshCredentials']();
 }
@@ -49,3 +61,7 @@ export function SearchMovies(arg1) {
 export function SetFavoriteMovies(arg1) {
   return window['go']['bindings']['Movies']['SetFavoriteMovies'](arg1);
 }
+
+export function UpdateTasteProfile(arg1) {
+  return window['go']['bindings']['Movies']['UpdateTasteProfile'](arg1);
+}
@@ -2,29 +2,58 @@
 // This file is automatically generated. DO NOT EDIT
 import {spotify} from '../models';
 import {session} from '../models';
+import {bindings} from '../models';
+
+export function AddToQueue(arg1:string,arg2:string):Promise<void>;
+
+export function AuthenticateSpotify():Promise<spotify.AuthError>;
 
 export function ClearSpotifyCredentials():Promise<void>;
 
 export function GetAuthStatus():Promise<Record<string, any>>;
 
+export function GetCrossMediaSuggestion(arg1:Array<session.MediaType>,arg2:session.RequestContext):Promise<spotify.SuggestedTrackInfo>;
+
 export function GetCurrentUser():Promise<spotify.UserProfile>;
 
+export function GetDevices():Promise<Array<spotify.Device>>;
+
+export function GetDiscoveryPlaylistTracks():Promise<Array<spotify.PlaylistItem>>;
+
+export function GetLibraryStatus():Promise<bindings.LibraryStatus>;
+
+export function GetPlaybackState():Promise<spotify.PlaybackState>;
+
 export function GetSavedTracks(arg1:number,arg2:number):Promise<spotify.SavedTracks>;
 
+export function GetTasteProfile():Promise<session.TasteProfile>;
+
 export function GetValidToken():Promise<string>;
 
 export function PausePlaybackOnDevice(arg1:string):Promise<void>;
 
 export function PlayTrackOnDevice(arg1:string,arg2:string):Promise<void>;
 
-export function ProvideSuggestionFeedback(arg1:session.Outcome,arg2:string,arg3:string,arg4:string):Promise<void>;
+export function ProvideSuggestionFeedback(arg1:session.Outcome,arg2:string,arg3:string,arg4:string,arg5:session.MusicUnit):Promise<void>;
+
+export function RefineSuggestion(arg1:string):Promise<spotify.SuggestedTrackInfo>;
 
 export function RefreshLLMClients():Promise<void>;
 
 export function RemoveTrack(arg1:string):Promise<void>;
 
-export function RequestNewSuggestion():Promise<spotify.SuggestedTrackInfo>;
+export function RequestNewSuggestion(arg1:session.RequestContext):Promise<spotify.SuggestedTrackInfo>;
+
+export function ResyncLibrary():Promise<number>;
 
 export function SaveTrack(arg1:string):Promise<void>;
 
+export function SearchLibrary(arg1:string,arg2:number):Promise<Array<spotify.SimpleTrack>>;
+
 export function SearchTracks(arg1:string,arg2:number):Promise<Array<spotify.SimpleTrack>>;
+
+export function SyncLibrary():Promise<number>;
+
+export function TransferPlayback(arg1:string,arg2:boolean):Promise<void>;
+
+export function UpdateTasteProfile(arg1:string):Promise<session.TasteProfile>;
@@ -2,6 +2,14 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddToQueue(arg1, arg2) {
+  return window['go']['bindings']['Music']['AddToQueue'](arg1, arg2);
+}
+
+export function AuthenticateSpotify() {
+  return window['go']['bindings']['Music']['AuthenticateSpotify']();
+}
+
 export function ClearSpotifyCredentials() {
   return window['go']['bindings']['Music']['ClearSpotifyCredentials']();
 }
@@ -10,14 +18,38 @@ export function GetAuthStatus() {
   return window['go']['bindings']['Music']['GetAuthStatus']();
 }
 
+export function GetCrossMediaSuggestion(arg1, arg2) {
+  return window['go']['bindings']['Music']['GetCrossMediaSuggestion'](arg1, arg2);
+}
+
 export function GetCurrentUser() {
   return window['go']['bindings']['Music']['GetCurrentUser']();
 }
 
+export function GetDevices() {
+  return window['go']['bindings']['Music']['GetDevices']();
+}
+
+export function GetDiscoveryPlaylistTracks() {
+  return window['go']['bindings']['Music']['GetDiscoveryPlaylistTracks']();
+}
+
+export function GetLibraryStatus() {
+  return window['go']['bindings']['Music']['GetLibraryStatus']();
+}
+
+export function GetPlaybackState() {
+  return window['go']['bindings']['Music']['GetPlaybackState']();
+}
+
 export function GetSavedTracks(arg1, arg2) {
   return window['go']['bindings']['Music']['GetSavedTracks'](arg1, arg2);
 }
 
+export function GetTasteProfile() {
+  return window['go']['bindings']['Music']['GetTasteProfile']();
+}
+
 export function GetValidToken() {
   return window['go']['bindings']['Music']['GetValidToken']();
 }
@@ -30,8 +62,12 @@ export function PlayTrackOnDevice(arg1, arg2) {
   return window['go']['bindings']['Music']['PlayTrackOnDevice'](arg1, arg2);
 }
 
-export function ProvideSuggestionFeedback(arg1, arg2, arg3, arg4) {
-  return window['go']['bindings']['Music']['ProvideSuggestionFeedback'](arg1, arg2, arg3, arg4);
+export function ProvideSuggestionFeedback(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['bindings']['Music']['ProvideSuggestionFeedback'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function RefineSuggestion(arg1) {
+  return window['go']['bindings']['Music']['RefineSuggestion'](arg1);
 }
 
 export function RefreshLLMClients() {
@@ -42,14 +78,34 @@ export function RemoveTrack(arg1) {
   return window['go']['bindings']['Music']['RemoveTrack'](arg1);
 }
 
-export function RequestNewSuggestion() {
-  return window['go']['bindings']['Music']['RequestNewSuggestion']();
+export function RequestNewSuggestion(arg1) {
+  return window['go']['bindings']['Music']['RequestNewSuggestion'](arg1);
+}
+
+export function ResyncLibrary() {
+  return window['go']['bindings']['Music']['ResyncLibrary']();
 }
 
 export function SaveTrack(arg1) {
   return window['go']['bindings']['Music']['SaveTrack'](arg1);
 }
 
+export function SearchLibrary(arg1, arg2) {
+  return window['go']['bindings']['Music']['SearchLibrary'](arg1, arg2);
+}
+
 export function SearchTracks(arg1, arg2) {
   return window['go']['bindings']['Music']['SearchTracks'](arg1, arg2);
 }
+
+export function SyncLibrary() {
+  return window['go']['bindings']['Music']['SyncLibrary']();
+}
+
+export function TransferPlayback(arg1, arg2) {
+  return window['go']['bindings']['Music']['TransferPlayback'](arg1, arg2);
+}
+
+export function UpdateTasteProfile(arg1) {
+  return window['go']['bindings']['Music']['UpdateTasteProfile'](arg1);
+}
@@ -1,18 +1,35 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {session} from '../models';
 
 export function GetChatGPTModel():Promise<string>;
 
 export function GetContinuousPlayback():Promise<boolean>;
 
+export function GetDiscoveryPlaylist():Promise<session.DiscoveryPlaylist>;
+
 export function GetGeminiModel():Promise<string>;
 
 export function GetLLMProvider():Promise<string>;
 
+export function GetMusicUnit():Promise<session.MusicUnit>;
+
+export function GetNovelty(arg1:session.MediaType):Promise<session.Novelty>;
+
+export function GetOwnedPlatforms():Promise<Array<session.GamePlatform>>;
+
 export function SetChatGPTModel(arg1:string):Promise<void>;
 
 export function SetContinuousPlayback(arg1:boolean):Promise<void>;
 
+export function SetDiscoveryPlaylist(arg1:boolean,arg2:string,arg3:number):Promise<void>;
+
 export function SetGeminiModel(arg1:string):Promise<void>;
 
 export function SetLLMProvider(arg1:string):Promise<void>;
+
+export function SetMusicUnit(arg1:session.MusicUnit):Promise<void>;
+
+export function SetNovelty(arg1:session.MediaType,arg2:session.Novelty):Promise<void>;
+
+export function SetOwnedPlatforms(arg1:Array<session.GamePlatform>):Promise<void>;
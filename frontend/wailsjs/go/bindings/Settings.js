@@ -10,6 +10,10 @@ export function GetContinuousPlayback() {
   return window['go']['bindings']['Settings']['GetContinuousPlayback']();
 }
 
+export function GetDiscoveryPlaylist() {
+  return window['go']['bindings']['Settings']['GetDiscoveryPlaylist']();
+}
+
 export function GetGeminiModel() {
   return window['go']['bindings']['Settings']['GetGeminiModel']();
 }
@@ -18,6 +22,18 @@ export function GetLLMProvider() {
   return window['go']['bindings']['Settings']['GetLLMProvider']();
 }
 
+export function GetMusicUnit() {
+  return window['go']['bindings']['Settings']['GetMusicUnit']();
+}
+
+export function GetNovelty(arg1) {
+  return window['go']['bindings']['Settings']['GetNovelty'](arg1);
+}
+
+export function GetOwnedPlatforms() {
+  return window['go']['bindings']['Settings']['GetOwnedPlatforms']();
+}
+
 export function SetChatGPTModel(arg1) {
   return window['go']['bindings']['Settings']['SetChatGPTModel'](arg1);
 }
@@ -26,6 +42,10 @@ export function SetContinuousPlayback(arg1) {
   return window['go']['bindings']['Settings']['SetContinuousPlayback'](arg1);
 }
 
+export function SetDiscoveryPlaylist(arg1, arg2, arg3) {
+  return window['go']['bindings']['Settings']['SetDiscoveryPlaylist'](arg1, arg2, arg3);
+}
+
 export function SetGeminiModel(arg1) {
   return window['go']['bindings']['Settings']['SetGeminiModel'](arg1);
 }
@@ -33,3 +53,15 @@ export function SetGeminiModel(arg1) {
 export function SetLLMProvider(arg1) {
   return window['go']['bindings']['Settings']['SetLLMProvider'](arg1);
 }
+
+export function SetMusicUnit(arg1) {
+  return window['go']['bindings']['Settings']['SetMusicUnit'](arg1);
+}
+
+export function SetNovelty(arg1, arg2) {
+  return window['go']['bindings']['Settings']['SetNovelty'](arg1, arg2);
+}
+
+export function SetOwnedPlatforms(arg1) {
+  return window['go']['bindings']['Settings']['SetOwnedPlatforms'](arg1);
+}
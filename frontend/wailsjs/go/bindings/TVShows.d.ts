@@ -2,14 +2,27 @@
 // This file is automatically generated. DO NOT EDIT
 import {session} from '../models';
 import {bindings} from '../models';
+import {tmdb} from '../models';
 
 export function AddToWatchlist(arg1:session.TVShow):Promise<void>;
 
+export function AdvanceEpisode(arg1:string):Promise<session.TVProgress>;
+
+export function GetCrossMediaSuggestion(arg1:Array<session.MediaType>,arg2:session.RequestContext):Promise<Record<string, any>>;
+
+export function GetEpisodeAlerts():Promise<Array<bindings.EpisodeAlert>>;
+
 export function GetFavoriteTVShows():Promise<Array<session.TVShow>>;
 
+export function GetSeasonDetails(arg1:number,arg2:number):Promise<tmdb.Season>;
+
+export function GetShowProgress():Promise<Array<session.TVProgress>>;
+
 export function GetTVShowDetails(arg1:number):Promise<bindings.TVShowWithSavedStatus>;
 
-export function GetTVShowSuggestion():Promise<Record<string, any>>;
+export function GetTVShowSuggestion(arg1:session.RequestContext):Promise<Record<string, any>>;
+
+export function GetTasteProfile():Promise<session.TasteProfile>;
 
 export function GetWatchlist():Promise<Array<session.TVShow>>;
 
@@ -17,12 +30,20 @@ export function HasValidCredentials():Promise<boolean>;
 
 export function ProvideSuggestionFeedback(arg1:session.Outcome,arg2:number):Promise<void>;
 
+export function RefineSuggestion(arg1:string):Promise<Record<string, any>>;
+
 export function RefreshCredentials():Promise<boolean>;
 
 export function RefreshLLMClients():Promise<void>;
 
 export function RemoveFromWatchlist(arg1:string):Promise<void>;
 
+export function RemoveShowProgress(arg1:string):Promise<void>;
+
 export function SearchTVShows(arg1:string):Promise<Array<bindings.TVShowWithSavedStatus>>;
 
 export function SetFavoriteTVShows(arg1:Array<session.TVShow>):Promise<void>;
+
+export function SetShowProgress(arg1:session.TVShow,arg2:number,arg3:number,arg4:number,arg5:session.WatchStatus):Promise<void>;
+
+export function UpdateTasteProfile(arg1:string):Promise<session.TasteProfile>;
@@ -6,16 +6,40 @@ export function AddToWatchlist(arg1) {
   return window['go']['bindings']['TVShows']['AddToWatchlist'](arg1);
 }
 
+export function AdvanceEpisode(arg1) {
+  return window['go']['bindings']['TVShows']['AdvanceEpisode'](arg1);
+}
+
+export function GetCrossMediaSuggestion(arg1, arg2) {
+  return window['go']['bindings']['TVShows']['GetCrossMediaSuggestion'](arg1, arg2);
+}
+
+export function GetEpisodeAlerts() {
+  return window['go']['bindings']['TVShows']['GetEpisodeAlerts']();
+}
+
 export function GetFavoriteTVShows() {
   return window['go']['bindings']['TVShows']['GetFavoriteTVShows']();
 }
 
+export function GetSeasonDetails(arg1, arg2) {
+  return window['go']['bindings']['TVShows']['GetSeasonDetails'](arg1, arg2);
+}
+
+export function GetShowProgress() {
+  return window['go']['bindings']['TVShows']['GetShowProgress']();
+}
+
 export function GetTVShowDetails(arg1) {
   return window['go']['bindings']['TVShows']['GetTVShowDetails'](arg1);
 }
 
-export function GetTVShowSuggestion() {
-  return window['go']['bindings']['TVShows']['GetTVShowSuggestion']();
+export function GetTVShowSuggestion(arg1) {
+  return window['go']['bindings']['TVShows']['GetTVShowSuggestion'](arg1);
+}
+
+export function GetTasteProfile() {
+  return window['go']['bindings']['TVShows']['GetTasteProfile']();
 }
 
 export function GetWatchlist() {
@@ -30,6 +54,10 @@ export function ProvideSuggestionFeedback(arg1, arg2) {
   return window['go']['bindings']['TVShows']['ProvideSuggestionFeedback'](arg1, arg2);
 }
 
+export function RefineSuggestion(arg1) {
+  return window['go']['bindings']['TVShows']['RefineSuggestion'](arg1);
+}
+
 export function RefreshCredentials() {
   return window['go']['bindings']['TVShows']['RefreshCredentials']();
 }
@@ -42,6 +70,10 @@ export function RemoveFromWatchlist(arg1) {
   return window['go']['bindings']['TVShows']['RemoveFromWatchlist'](arg1);
 }
 
+export function RemoveShowProgress(arg1) {
+  return window['go']['bindings']['TVShows']['RemoveShowProgress'](arg1);
+}
+
 export function SearchTVShows(arg1) {
   return window['go']['bindings']['TVShows']['SearchTVShows'](arg1);
 }
@@ -49,3 +81,11 @@ export function SearchTVShows(arg1) {
 export function SetFavoriteTVShows(arg1) {
   return window['go']['bindings']['TVShows']['SetFavoriteTVShows'](arg1);
 }
+
+export function SetShowProgress(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['bindings']['TVShows']['SetShowProgress'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function UpdateTasteProfile(arg1) {
+  return window['go']['bindings']['TVShows']['UpdateTasteProfile'](arg1);
+}
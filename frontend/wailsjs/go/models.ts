@@ -1,5 +1,37 @@
 export namespace bindings {
 	
+	export class BookEdition {
+	    title: string;
+	    author: string;
+	    key: string;
+	    work_key?: string;
+	    cover_path: string;
+	    publishers?: string[];
+	    publish_date?: string;
+	    number_of_pages?: number;
+	    physical_format?: string;
+	    isbn_10?: string[];
+	    isbn_13?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BookEdition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.author = source["author"];
+	        this.key = source["key"];
+	        this.work_key = source["work_key"];
+	        this.cover_path = source["cover_path"];
+	        this.publishers = source["publishers"];
+	        this.publish_date = source["publish_date"];
+	        this.number_of_pages = source["number_of_pages"];
+	        this.physical_format = source["physical_format"];
+	        this.isbn_10 = source["isbn_10"];
+	        this.isbn_13 = source["isbn_13"];
+	    }
+	}
 	export class BookWithSavedStatus {
 	    title: string;
 	    author: string;
@@ -40,6 +72,46 @@ export namespace bindings {
 	        this.slug = source["slug"];
 	    }
 	}
+	export class EpisodeAlert {
+	    show_id: number;
+	    show_name: string;
+	    poster_path: string;
+	    upcoming: boolean;
+	    new_season: boolean;
+	    episode: tmdb.Episode;
+	
+	    static createFrom(source: any = {}) {
+	        return new EpisodeAlert(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.show_id = source["show_id"];
+	        this.show_name = source["show_name"];
+	        this.poster_path = source["poster_path"];
+	        this.upcoming = source["upcoming"];
+	        this.new_season = source["new_season"];
+	        this.episode = this.convertValues(source["episode"], tmdb.Episode);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Publisher {
 	    id: number;
 	    name: string;
@@ -199,6 +271,20 @@ export namespace bindings {
 		}
 	}
 	
+	export class LibraryStatus {
+	    trackCount: number;
+	    syncedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LibraryStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.trackCount = source["trackCount"];
+	        this.syncedAt = source["syncedAt"];
+	    }
+	}
 	export class MovieWithSavedStatus {
 	    id: number;
 	    title: string;
@@ -231,8 +317,54 @@ export namespace bindings {
 	}
 	
 	
+	export class PlayNextPick {
+	    entry: session.BacklogEntry;
+	    weeks: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlayNextPick(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entry = this.convertValues(source["entry"], session.BacklogEntry);
+	        this.weeks = source["weeks"];
+	    }
 	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class SubjectWeight {
+	    subject: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SubjectWeight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subject = source["subject"];
+	        this.count = source["count"];
+	    }
+	}
 	export class TVShowWithSavedStatus {
 	    id: number;
 	    name: string;
@@ -270,6 +402,28 @@ export namespace bindings {
 
 export namespace session {
 	
+	export enum MusicUnit {
+	    track = "track",
+	    album = "album",
+	    artist = "artist",
+	}
+	export enum MediaType {
+	    music = "music",
+	    movie = "movie",
+	    book = "book",
+	    tv = "tv",
+	    video_game = "video_game",
+	}
+	export enum Company {
+	    solo = "solo",
+	    partner = "partner",
+	    kids = "kids",
+	}
+	export enum EnergyLevel {
+	    low = "low",
+	    medium = "medium",
+	    high = "high",
+	}
 	export enum Outcome {
 	    liked = "liked",
 	    disliked = "disliked",
@@ -277,6 +431,84 @@ export namespace session {
 	    added = "added",
 	    pending = "pending",
 	}
+	export enum WatchStatus {
+	    watching = "watching",
+	    paused = "paused",
+	    finished = "finished",
+	}
+	export enum BacklogStatus {
+	    unplayed = "unplayed",
+	    playing = "playing",
+	    beaten = "beaten",
+	    abandoned = "abandoned",
+	}
+	export enum Novelty {
+	    familiar = "familiar",
+	    balanced = "balanced",
+	    adventurous = "adventurous",
+	}
+	export class VideoGame {
+	    title: string;
+	    developer: string;
+	    publisher: string;
+	    platforms: string[];
+	    cover_path: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VideoGame(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.developer = source["developer"];
+	        this.publisher = source["publisher"];
+	        this.platforms = source["platforms"];
+	        this.cover_path = source["cover_path"];
+	    }
+	}
+	export class BacklogEntry {
+	    game: VideoGame;
+	    playtime: number;
+	    priority: number;
+	    status: BacklogStatus;
+	    added_at: number;
+	    started_at?: number;
+	    finished_at?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BacklogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.game = this.convertValues(source["game"], VideoGame);
+	        this.playtime = source["playtime"];
+	        this.priority = source["priority"];
+	        this.status = source["status"];
+	        this.added_at = source["added_at"];
+	        this.started_at = source["started_at"];
+	        this.finished_at = source["finished_at"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Book {
 	    title: string;
 	    author: string;
@@ -293,6 +525,40 @@ export namespace session {
 	        this.cover_path = source["cover_path"];
 	    }
 	}
+	export class DiscoveryPlaylist {
+	    enabled: boolean;
+	    name: string;
+	    playlist_id: string;
+	    max_tracks: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscoveryPlaylist(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.name = source["name"];
+	        this.playlist_id = source["playlist_id"];
+	        this.max_tracks = source["max_tracks"];
+	    }
+	}
+	export class GamePlatform {
+	    id: number;
+	    name: string;
+	    slug: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GamePlatform(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.slug = source["slug"];
+	    }
+	}
 	export class Movie {
 	    title: string;
 	    director: string;
@@ -311,6 +577,26 @@ export namespace session {
 	        this.poster_path = source["poster_path"];
 	    }
 	}
+	export class RequestContext {
+	    mood?: string;
+	    time_available?: number;
+	    company?: Company;
+	    energy?: EnergyLevel;
+	    occasion?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RequestContext(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mood = source["mood"];
+	        this.time_available = source["time_available"];
+	        this.company = source["company"];
+	        this.energy = source["energy"];
+	        this.occasion = source["occasion"];
+	    }
+	}
 	export class TVShow {
 	    title: string;
 	    director: string;
@@ -329,24 +615,63 @@ export namespace session {
 	        this.poster_path = source["poster_path"];
 	    }
 	}
-	export class VideoGame {
-	    title: string;
-	    developer: string;
-	    publisher: string;
-	    platforms: string[];
-	    cover_path: string;
+	export class TVProgress {
+	    show: TVShow;
+	    show_id: number;
+	    season: number;
+	    episode: number;
+	    status: WatchStatus;
+	    updated_at: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new VideoGame(source);
+	        return new TVProgress(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.title = source["title"];
-	        this.developer = source["developer"];
-	        this.publisher = source["publisher"];
-	        this.platforms = source["platforms"];
-	        this.cover_path = source["cover_path"];
+	        this.show = this.convertValues(source["show"], TVShow);
+	        this.show_id = source["show_id"];
+	        this.season = source["season"];
+	        this.episode = source["episode"];
+	        this.status = source["status"];
+	        this.updated_at = source["updated_at"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TasteProfile {
+	    text: string;
+	    generated_at: number;
+	    answered: number;
+	    edited?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TasteProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.generated_at = source["generated_at"];
+	        this.answered = source["answered"];
+	        this.edited = source["edited"];
 	    }
 	}
 
@@ -354,6 +679,55 @@ export namespace session {
 
 export namespace spotify {
 	
+	export enum AuthErrorCode {
+	    timeout = "timeout",
+	    denied = "denied",
+	    state_mismatch = "state_mismatch",
+	    port_unavailable = "port_unavailable",
+	    browser_failed = "browser_failed",
+	    exchange_failed = "exchange_failed",
+	    cancelled = "cancelled",
+	}
+	export class Artist {
+	    id: string;
+	    name: string;
+	    genres: string[];
+	    images?: Image[];
+	    popularity?: number;
+	    uri?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Artist(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.genres = source["genres"];
+	        this.images = this.convertValues(source["images"], Image);
+	        this.popularity = source["popularity"];
+	        this.uri = source["uri"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Image {
 	    url: string;
 	    height: number;
@@ -374,6 +748,10 @@ export namespace spotify {
 	    id: string;
 	    name: string;
 	    images: Image[];
+	    artists?: Artist[];
+	    release_date?: string;
+	    total_tracks?: number;
+	    uri?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Album(source);
@@ -384,6 +762,10 @@ export namespace spotify {
 	        this.id = source["id"];
 	        this.name = source["name"];
 	        this.images = this.convertValues(source["images"], Image);
+	        this.artists = this.convertValues(source["artists"], Artist);
+	        this.release_date = source["release_date"];
+	        this.total_tracks = source["total_tracks"];
+	        this.uri = source["uri"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -404,20 +786,43 @@ export namespace spotify {
 		    return a;
 		}
 	}
-	export class Artist {
+	
+	export class AuthError {
+	    code: AuthErrorCode;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.message = source["message"];
+	    }
+	}
+	export class Device {
 	    id: string;
 	    name: string;
-	    genres: string[];
+	    type: string;
+	    is_active: boolean;
+	    is_private_session: boolean;
+	    is_restricted: boolean;
+	    volume_percent: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Artist(source);
+	        return new Device(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.genres = source["genres"];
+	        this.type = source["type"];
+	        this.is_active = source["is_active"];
+	        this.is_private_session = source["is_private_session"];
+	        this.is_restricted = source["is_restricted"];
+	        this.volume_percent = source["volume_percent"];
 	    }
 	}
 	
@@ -428,6 +833,7 @@ export namespace spotify {
 	    album: Album;
 	    preview_url: string;
 	    uri: string;
+	    popularity?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Track(source);
@@ -441,6 +847,83 @@ export namespace spotify {
 	        this.album = this.convertValues(source["album"], Album);
 	        this.preview_url = source["preview_url"];
 	        this.uri = source["uri"];
+	        this.popularity = source["popularity"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PlaybackState {
+	    device: Device;
+	    is_playing: boolean;
+	    shuffle_state: boolean;
+	    repeat_state: string;
+	    progress_ms: number;
+	    timestamp: number;
+	    currently_playing_type: string;
+	    item?: Track;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlaybackState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.device = this.convertValues(source["device"], Device);
+	        this.is_playing = source["is_playing"];
+	        this.shuffle_state = source["shuffle_state"];
+	        this.repeat_state = source["repeat_state"];
+	        this.progress_ms = source["progress_ms"];
+	        this.timestamp = source["timestamp"];
+	        this.currently_playing_type = source["currently_playing_type"];
+	        this.item = this.convertValues(source["item"], Track);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PlaylistItem {
+	    track?: Track;
+	    added_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlaylistItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.track = this.convertValues(source["track"], Track);
+	        this.added_at = source["added_at"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -543,6 +1026,7 @@ export namespace spotify {
 	    albumArtUrl: string;
 	    previewUrl: string;
 	    uri: string;
+	    popularity?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new SimpleTrack(source);
@@ -559,6 +1043,7 @@ export namespace spotify {
 	        this.albumArtUrl = source["albumArtUrl"];
 	        this.previewUrl = source["previewUrl"];
 	        this.uri = source["uri"];
+	        this.popularity = source["popularity"];
 	    }
 	}
 	export class SuggestedTrackInfo {
@@ -570,6 +1055,7 @@ export namespace spotify {
 	    albumArtUrl?: string;
 	    reason?: string;
 	    uri?: string;
+	    unit?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new SuggestedTrackInfo(source);
@@ -585,6 +1071,7 @@ export namespace spotify {
 	        this.albumArtUrl = source["albumArtUrl"];
 	        this.reason = source["reason"];
 	        this.uri = source["uri"];
+	        this.unit = source["unit"];
 	    }
 	}
 	
@@ -631,3 +1118,80 @@ export namespace spotify {
 
 }
 
+export namespace tmdb {
+	
+	export class Episode {
+	    id: number;
+	    name: string;
+	    overview: string;
+	    air_date: string;
+	    season_number: number;
+	    episode_number: number;
+	    runtime: number;
+	    still_path: string;
+	    vote_average: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Episode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.overview = source["overview"];
+	        this.air_date = source["air_date"];
+	        this.season_number = source["season_number"];
+	        this.episode_number = source["episode_number"];
+	        this.runtime = source["runtime"];
+	        this.still_path = source["still_path"];
+	        this.vote_average = source["vote_average"];
+	    }
+	}
+	export class Season {
+	    id: number;
+	    name: string;
+	    overview: string;
+	    poster_path: string;
+	    season_number: number;
+	    air_date: string;
+	    episode_count: number;
+	    episodes?: Episode[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Season(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.overview = source["overview"];
+	        this.poster_path = source["poster_path"];
+	        this.season_number = source["season_number"];
+	        this.air_date = source["air_date"];
+	        this.episode_count = source["episode_count"];
+	        this.episodes = this.convertValues(source["episodes"], Episode);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
@@ -6,12 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"interestnaut/internal/directives"
-	"interestnaut/internal/gemini"
 	"interestnaut/internal/llm"
-	"interestnaut/internal/openai"
 	"interestnaut/internal/openlibrary"
+	"interestnaut/internal/ranking"
 	"interestnaut/internal/session"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -27,47 +27,71 @@ type BookWithSavedStatus struct {
 	Description string   `json:"description,omitempty"`
 }
 
+// BookEdition represents a specific published edition of a book
+type BookEdition struct {
+	Title          string   `json:"title"`
+	Author         string   `json:"author"`
+	Key            string   `json:"key"`
+	WorkKey        string   `json:"work_key,omitempty"`
+	CoverPath      string   `json:"cover_path"`
+	Publishers     []string `json:"publishers,omitempty"`
+	PublishDate    string   `json:"publish_date,omitempty"`
+	NumberOfPages  int      `json:"number_of_pages,omitempty"`
+	PhysicalFormat string   `json:"physical_format,omitempty"`
+	ISBN10         []string `json:"isbn_10,omitempty"`
+	ISBN13         []string `json:"isbn_13,omitempty"`
+}
+
+// maxEditions caps how many editions are listed for a single work
+const maxEditions = 50
+
+const (
+	// maxProfileFavorites caps how many favorites are looked up when building the subject profile,
+	// since each one costs a search request
+	maxProfileFavorites = 25
+	// maxProfileSubjects is how many of the top subjects are queried for discovery candidates
+	maxProfileSubjects     = 5
+	subjectWorksLimit      = 20
+	maxDiscoveryCandidates = 30
+	maxDiscoveryAttempts   = 2
+)
+
+// SubjectWeight is how many of the user's favorite books are filed under a subject
+type SubjectWeight struct {
+	Subject string `json:"subject"`
+	Count   int    `json:"count"`
+}
+
+// bookCandidate is a work pulled from a subject listing for the LLM to rank
+type bookCandidate struct {
+	book     session.Book
+	key      string
+	subjects []string
+	year     int
+}
+
 type Books struct {
 	olClient               *openlibrary.Client
-	llmClients             map[string]llm.Client[session.Book]
+	llmClients             *llmClients[session.Book]
+	recommender            *Recommender[session.Book, *BookWithSavedStatus, map[string]interface{}]
 	manager                session.Manager[session.Book]
 	centralManager         session.CentralManager
 	baselineFunc, taskFunc func() string
-	mu                     sync.Mutex
+	pipeline               *pipeline[session.Book, map[string]interface{}]
+
+	profileMu        sync.Mutex
+	subjectProfile   []SubjectWeight
+	profileFavorites string // Fingerprint of the favorites the profile was built from
 }
 
 func NewBooks(_ context.Context, cm session.CentralManager) (*Books, error) {
 	client := openlibrary.NewClient()
 
-	// Create a map of LLM clients for both providers
-	llmClients := make(map[string]llm.Client[session.Book])
-
-	// Initialize OpenAI client
-	openaiClient, err := openai.NewClient[session.Book](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-	} else {
-		llmClients["openai"] = openaiClient
-	}
-
-	// Initialize Gemini client
-	geminiClient, err := gemini.NewClient[session.Book](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create Gemini client: %v", err)
-	} else {
-		llmClients["gemini"] = geminiClient
-	}
-
-	// No longer fail if no clients were created - they can be refreshed later
-	if len(llmClients) == 0 {
-		log.Printf("WARNING: No LLM clients available, credentials may need to be added")
-	}
-
 	manager := cm.Book()
 
 	b := &Books{
 		olClient:       client,
-		llmClients:     llmClients,
+		llmClients:     newLLMClients[session.Book](cm),
 		manager:        manager,
 		centralManager: cm,
 	}
@@ -82,6 +106,44 @@ func NewBooks(_ context.Context, cm session.CentralManager) (*Books, error) {
 		return directives.GetBookBaseline(context.Background(), favorites)
 	}
 
+	// Books have no popularity signal to check against, so novelty only goes into the prompt
+	b.recommender = &Recommender[session.Book, *BookWithSavedStatus, map[string]interface{}]{
+		kind:      "book",
+		media:     session.BookMedia,
+		cm:        cm,
+		manager:   manager,
+		clients:   b.llmClients,
+		resolve:   b.resolveSuggestedBook,
+		mapResult: mapSuggestedBook,
+		fallback: func() map[string]interface{} {
+			// A fallback book with a warning message
+			return map[string]interface{}{
+				"title":       "LLM Suggestion Unavailable",
+				"author":      "System Message",
+				"cover_path":  "",
+				"description": "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
+				"reasoning":   "No LLM clients are available. Please check your API keys in settings.",
+				"key":         "",
+			}
+		},
+		library: b.libraryItems,
+		details: func(s *llm.SuggestionResponse[session.Book], book *BookWithSavedStatus) (matchDetails, matchDetails) {
+			return suggestedBookDetails(s), foundBookDetails(book)
+		},
+		validate: validateBookSuggestion,
+	}
+
+	b.pipeline = newPipeline(&pipeline[session.Book, map[string]interface{}]{
+		media:   session.BookMedia,
+		cm:      cm,
+		manager: b.manager,
+		clients: b.llmClients,
+		session: func(ctx context.Context) (*session.Session[session.Book], string) {
+			return b.manager.GetOrCreateSession(ctx, b.manager.Key(), b.taskFunc, b.baselineFunc), "book"
+		},
+		prepare: b.prepare,
+	})
+
 	return b, nil
 }
 
@@ -176,6 +238,147 @@ func (b *Books) GetReadList() ([]session.Book, error) {
 	return b.centralManager.Queue().GetBooks(), nil
 }
 
+// LookupISBN finds the edition of a book with the given ISBN-10 or ISBN-13
+func (b *Books) LookupISBN(isbn string) (*BookEdition, error) {
+	ctx := context.Background()
+
+	edition, err := b.olClient.GetEditionByISBN(ctx, isbn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up ISBN: %w", err)
+	}
+
+	var work *openlibrary.WorksResponse
+	if len(edition.Works) > 0 {
+		work, err = b.olClient.GetBookDetails(ctx, edition.Works[0].Key)
+		if err != nil {
+			log.Printf("WARNING: Failed to get work for ISBN %s: %v", isbn, err)
+		}
+	}
+
+	// Editions don't always list authors, so fall back to the work's
+	var authorKey string
+	if len(edition.Authors) > 0 {
+		authorKey = edition.Authors[0].Key
+	} else if work != nil && len(work.AuthorKeys) > 0 {
+		authorKey = work.AuthorKeys[0].Author.Key
+	}
+
+	authorName := ""
+	if authorKey != "" {
+		author, err := b.olClient.GetAuthorDetails(ctx, authorKey)
+		if err == nil && author != nil {
+			authorName = author.Name
+		}
+	}
+
+	result := b.toBookEdition(edition, authorName)
+	if result.CoverPath == "" && work != nil && len(work.Covers) > 0 {
+		result.CoverPath = b.olClient.GetCoverURL(work.Covers[0])
+	}
+
+	return &result, nil
+}
+
+// GetEditions lists the editions of a work along with their publishers and page counts
+func (b *Books) GetEditions(workKey string) ([]BookEdition, error) {
+	ctx := context.Background()
+
+	resp, err := b.olClient.GetEditions(ctx, workKey, maxEditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get editions: %w", err)
+	}
+
+	// Every edition shares the work's author, so only look it up once
+	authorName := ""
+	work, err := b.olClient.GetBookDetails(ctx, workKey)
+	if err == nil && len(work.AuthorKeys) > 0 {
+		author, err := b.olClient.GetAuthorDetails(ctx, work.AuthorKeys[0].Author.Key)
+		if err == nil && author != nil {
+			authorName = author.Name
+		}
+	}
+
+	editions := make([]BookEdition, len(resp.Entries))
+	for i := range resp.Entries {
+		editions[i] = b.toBookEdition(&resp.Entries[i], authorName)
+	}
+
+	return editions, nil
+}
+
+// AddFavoriteByISBN looks up a book by ISBN and adds it to the user's favorites
+func (b *Books) AddFavoriteByISBN(isbn string) (*session.Book, error) {
+	book, err := b.bookFromISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.centralManager.Favorites().AddBook(*book); err != nil {
+		return nil, fmt.Errorf("failed to add book to favorites: %w", err)
+	}
+
+	log.Printf("Added '%s' by '%s' to favorites from ISBN %s", book.Title, book.Author, isbn)
+	return book, nil
+}
+
+// AddToReadListByISBN looks up a book by ISBN and adds it to the user's reading list
+func (b *Books) AddToReadListByISBN(isbn string) (*session.Book, error) {
+	book, err := b.bookFromISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.AddToReadList(*book); err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// bookFromISBN resolves an ISBN to the session representation of the book
+func (b *Books) bookFromISBN(isbn string) (*session.Book, error) {
+	edition, err := b.LookupISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	if edition.Author == "" {
+		return nil, fmt.Errorf("no author found for ISBN %s", isbn)
+	}
+
+	return &session.Book{
+		Title:     edition.Title,
+		Author:    edition.Author,
+		CoverPath: edition.CoverPath,
+	}, nil
+}
+
+// toBookEdition converts an Open Library edition for the frontend
+func (b *Books) toBookEdition(edition *openlibrary.Edition, authorName string) BookEdition {
+	result := BookEdition{
+		Title:          edition.Title,
+		Author:         authorName,
+		Key:            edition.Key,
+		Publishers:     edition.Publishers,
+		PublishDate:    edition.PublishDate,
+		NumberOfPages:  edition.NumberOfPages,
+		PhysicalFormat: edition.PhysicalFormat,
+		ISBN10:         edition.ISBN10,
+		ISBN13:         edition.ISBN13,
+	}
+
+	if len(edition.Works) > 0 {
+		result.WorkKey = edition.Works[0].Key
+	}
+
+	// Open Library uses -1 for a removed cover
+	if len(edition.Covers) > 0 && edition.Covers[0] > 0 {
+		result.CoverPath = b.olClient.GetCoverURL(edition.Covers[0])
+	}
+
+	return result
+}
+
 // SearchBooks searches for books using the Open Library API
 func (b *Books) SearchBooks(query string) ([]*BookWithSavedStatus, error) {
 	resp, err := b.olClient.SearchBooks(context.Background(), query)
@@ -275,69 +478,50 @@ func (b *Books) GetBookDetails(workKey string) (*BookWithSavedStatus, error) {
 }
 
 // GetBookSuggestion requests a book suggestion from the LLM
-func (b *Books) GetBookSuggestion() (map[string]interface{}, error) {
-	ctx := context.Background()
-	sess := b.manager.GetOrCreateSession(ctx, b.manager.Key(), b.taskFunc, b.baselineFunc)
+func (b *Books) GetBookSuggestion(requestContext session.RequestContext) (map[string]interface{}, error) {
+	return b.pipeline.next(requestContext)
+}
 
-	// Get current LLM provider from settings
-	provider := b.centralManager.Settings().GetLLMProvider()
+// RefineSuggestion asks for a follow-up to the latest suggestion, steered by the user's instruction,
+// e.g. "like this, but darker"
+func (b *Books) RefineSuggestion(instruction string) (map[string]interface{}, error) {
+	return b.pipeline.refine(instruction)
+}
 
-	// Get the appropriate client
-	llmClient, ok := b.llmClients[provider]
-	if !ok {
-		// Fall back to openai if the requested provider is not available
-		log.Printf("WARNING: Requested LLM provider '%s' not available, falling back to openai", provider)
-		llmClient, ok = b.llmClients["openai"]
-		if !ok {
-			log.Printf("WARNING: No LLM clients available, providing a default suggestion")
-			// Create a fallback book object with a warning message
-			fallbackBook := &BookWithSavedStatus{
-				Title:       "LLM Suggestion Unavailable",
-				Author:      "System Message",
-				Key:         "",
-				CoverPath:   "",
-				Description: "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
-			}
+// GetCrossMediaSuggestion suggests based on the user's taste in other kinds of media, e.g. a book
+// based on their movies and games
+func (b *Books) GetCrossMediaSuggestion(
+	sources []session.MediaType,
+	requestContext session.RequestContext,
+) (map[string]interface{}, error) {
+	return b.pipeline.crossMedia(sources, requestContext)
+}
 
-			return map[string]interface{}{
-				"title":       fallbackBook.Title,
-				"author":      fallbackBook.Author,
-				"cover_path":  fallbackBook.CoverPath,
-				"description": fallbackBook.Description,
-				"reasoning":   "No LLM clients are available. Please check your API keys in settings.",
-				"key":         "",
-			}, nil
-		}
-	}
+// GetTasteProfile returns the LLM's summary of the user's book taste, generating or refreshing it first
+func (b *Books) GetTasteProfile() (*session.TasteProfile, error) {
+	return b.pipeline.profile()
+}
 
-	// Request a new suggestion
-	messages, err := llmClient.ComposeMessages(ctx, &sess.Content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to compose message for LLM: %w", err)
-	}
+// UpdateTasteProfile replaces the book taste profile with the user's own version, which is kept when it's
+// next refreshed
+func (b *Books) UpdateTasteProfile(text string) (*session.TasteProfile, error) {
+	return b.pipeline.updateProfile(text)
+}
 
-	suggestion, err := llmClient.SendMessages(ctx, messages...)
-	if err != nil {
-		// Check if this is a parsing error and try using the error followup
-		if err.Error() != "" && (strings.Contains(err.Error(), "failed to parse suggestion") ||
-			strings.Contains(err.Error(), "could not parse suggestion")) {
-			log.Printf("Initial LLM response could not be parsed, attempting error followup")
-
-			// If we got a suggestion object but it wasn't valid, try error followup
-			if suggestion != nil {
-				// Try error followup with original messages
-				suggestion, err = llmClient.ErrorFollowup(ctx, suggestion, messages...)
-				if err != nil {
-					return nil, fmt.Errorf("error followup also failed: %w", err)
-				}
-			} else {
-				return nil, fmt.Errorf("failed to get suggestion from LLM: %w", err)
-			}
-		} else {
-			return nil, fmt.Errorf("failed to get suggestion from LLM: %w", err)
-		}
-	}
+// prepare gets and resolves a book suggestion in the session without recording it
+func (b *Books) prepare(
+	ctx context.Context,
+	sess *session.Session[session.Book],
+	_ string,
+	requestContext session.RequestContext,
+	notes ...string,
+) (*pendingSuggestion[map[string]interface{}], error) {
+	return b.recommender.recommend(ctx, sess, requestContext, notes...)
+}
 
+// validateBookSuggestion makes sure a suggestion names its author, digging the title and author out of
+// the raw response when the LLM put them somewhere unexpected
+func validateBookSuggestion(suggestion *llm.SuggestionResponse[session.Book]) error {
 	// Check if title and author are present
 	if (suggestion.Content.Title == "" && suggestion.Title == "") || (suggestion.Content.Author == "" && suggestion.Artist == "") {
 		log.Printf("ERROR: LLM content missing title or author. Content: %+v", suggestion)
@@ -394,82 +578,40 @@ func (b *Books) GetBookSuggestion() (map[string]interface{}, error) {
 
 		// Check again after direct extraction
 		if (suggestion.Content.Title == "" && suggestion.Title == "") || (suggestion.Content.Author == "" && suggestion.Artist == "") {
-			return nil, errors.New("LLM content response was missing title or author")
+			return errors.New("LLM content response was missing title or author")
 		}
 	}
 
-	// Use either the content fields or the top-level fields
-	title := suggestion.Content.Title
-	if title == "" {
-		title = suggestion.Title
-	}
+	return nil
+}
 
-	author := suggestion.Content.Author
-	if author == "" {
-		author = suggestion.Artist
-	}
+// resolveSuggestedBook finds the closest match on Open Library by title, author and year
+func (b *Books) resolveSuggestedBook(
+	_ context.Context,
+	suggestion *llm.SuggestionResponse[session.Book],
+) (*BookWithSavedStatus, error) {
+	suggested := suggestedBookDetails(suggestion)
+	title, author := suggested.title, firstNonEmpty(suggested.creators...)
 
 	// Try to find the book on Open Library
 	searchQuery := fmt.Sprintf("%s %s", title, author)
 	books, err := b.SearchBooks(searchQuery)
-	if err != nil || len(books) == 0 {
-		// Even if we can't find the book on Open Library, we can still use the suggestion
-		// Just without a proper cover or additional metadata
-		log.Printf("WARNING: Could not find book '%s' by '%s' on Open Library: %v", title, author, err)
-
-		bookSuggestion := session.Suggestion[session.Book]{
-			PrimaryGenre: suggestion.PrimaryGenre,
-			UserOutcome:  session.Pending,
-			Reasoning:    suggestion.Reason,
-			Content: session.Book{
-				Title:     title,
-				Author:    author,
-				CoverPath: "", // No cover path available
-			},
-		}
-
-		if sErr := b.manager.AddSuggestion(ctx, sess, bookSuggestion); sErr != nil {
-			log.Printf("ERROR: Failed to add suggestion: %v", sErr)
-			return nil, fmt.Errorf("failed to add suggestion: %w", sErr)
-		}
-
-		// Return the suggestion even without additional metadata
-		return map[string]interface{}{
-			"title":         title,
-			"author":        author,
-			"cover_path":    "",
-			"reasoning":     suggestion.Reason,
-			"primary_genre": suggestion.PrimaryGenre,
-			"description":   suggestion.Reason,
-		}, nil
+	if err != nil {
+		log.Printf("ERROR: Failed to search for suggested book '%s' by '%s': %v", title, author, err)
+		return nil, fmt.Errorf("failed to search for suggested book '%s' by '%s': %w", title, author, err)
 	}
-
-	// Find the best match from the search results
-	var bestMatch *BookWithSavedStatus
-	bestSimilarity := 0.0
-
-	for _, book := range books {
-		titleSimilarity := calculateSimilarity(book.Title, title)
-		authorSimilarity := calculateSimilarity(book.Author, author)
-
-		// Weighted average of title and author similarity
-		similarity := titleSimilarity*0.7 + authorSimilarity*0.3
-
-		if similarity > bestSimilarity {
-			bestSimilarity = similarity
-			bestMatch = book
-		}
+	if len(books) == 0 {
+		return nil, missed("'%s' by '%s' could not be found on Open Library. Suggest a different book.", title, author)
 	}
 
-	if bestMatch == nil {
-		bestMatch = books[0] // Default to first result if no good match found
-	}
+	// Find the best match from the search results
+	matched := bestMatch(suggested, books, foundBookDetails)
 
 	// Fetch detailed book information to get the description
 	// Only try if we have a proper key
 	var description string
-	if strings.HasPrefix(bestMatch.Key, "/works/") {
-		bookDetails, detailErr := b.olClient.GetBookDetails(context.Background(), bestMatch.Key)
+	if strings.HasPrefix(matched.Key, "/works/") {
+		bookDetails, detailErr := b.olClient.GetBookDetails(context.Background(), matched.Key)
 		if detailErr == nil && bookDetails != nil {
 			// Extract description from the detailed response
 			if bookDetails.Description != nil {
@@ -482,26 +624,277 @@ func (b *Books) GetBookSuggestion() (map[string]interface{}, error) {
 					}
 				}
 				// Update the best match with the description
-				bestMatch.Description = description
+				matched.Description = description
 			}
 		}
 	}
 
 	// If we still don't have a description, use the reasoning
-	if bestMatch.Description == "" {
-		bestMatch.Description = suggestion.Reason
+	if matched.Description == "" {
+		matched.Description = suggestion.Reason
+	}
+
+	return matched, nil
+}
+
+// suggestedBookDetails is what a book suggestion is verified on, using either the content fields or the
+// top-level fields
+func suggestedBookDetails(suggestion *llm.SuggestionResponse[session.Book]) matchDetails {
+	return matchDetails{
+		title:    firstNonEmpty(suggestion.Content.Title, suggestion.Title),
+		year:     yearOf(string(suggestion.Year)),
+		creators: nonEmpty(firstNonEmpty(suggestion.Content.Author, suggestion.Artist)),
+	}
+}
+
+// foundBookDetails is what Open Library knows about a book to verify a suggestion against
+func foundBookDetails(book *BookWithSavedStatus) matchDetails {
+	return matchDetails{title: book.Title, year: book.Year, creators: nonEmpty(book.Author)}
+}
+
+// mapSuggestedBook records the book as Open Library knows it
+func mapSuggestedBook(
+	suggestion *llm.SuggestionResponse[session.Book],
+	book *BookWithSavedStatus,
+) (session.Suggestion[session.Book], map[string]interface{}) {
+	recorded := session.Suggestion[session.Book]{
+		Year: book.Year,
+		Content: session.Book{
+			Title:     book.Title,
+			Author:    book.Author,
+			CoverPath: book.CoverPath,
+		},
+	}
+
+	return recorded, map[string]interface{}{
+		"title":         book.Title,
+		"author":        book.Author,
+		"cover_path":    book.CoverPath,
+		"reasoning":     suggestion.Reason,
+		"primary_genre": suggestion.PrimaryGenre,
+		"key":           book.Key,
+		"description":   book.Description,
+	}
+}
+
+// GetSubjectProfile returns the subjects the user's favorite books are filed under, most common first
+func (b *Books) GetSubjectProfile() ([]SubjectWeight, error) {
+	favorites := b.centralManager.Favorites().GetBooks()
+	if len(favorites) > maxProfileFavorites {
+		// Prefer the most recently added favorites
+		favorites = favorites[len(favorites)-maxProfileFavorites:]
+	}
+
+	keys := make([]string, len(favorites))
+	for i, f := range favorites {
+		keys[i] = f.Key()
+	}
+	fingerprint := strings.Join(keys, "|")
+
+	b.profileMu.Lock()
+	if b.subjectProfile != nil && b.profileFavorites == fingerprint {
+		cached := append([]SubjectWeight{}, b.subjectProfile...)
+		b.profileMu.Unlock()
+		return cached, nil
+	}
+	b.profileMu.Unlock()
+
+	// Open Library is looked up without the lock, so a slow lookup doesn't hold up callers with a cached profile.
+	// Callers that miss at the same time each look it up, and the profile is the same either way.
+	ctx := context.Background()
+	counts := make(map[string]int)
+	for _, favorite := range favorites {
+		for _, subject := range b.favoriteSubjects(ctx, favorite) {
+			counts[subject]++
+		}
+	}
+
+	profile := make([]SubjectWeight, 0, len(counts))
+	for subject, count := range counts {
+		profile = append(profile, SubjectWeight{Subject: subject, Count: count})
+	}
+	sort.Slice(profile, func(i, j int) bool {
+		if profile[i].Count != profile[j].Count {
+			return profile[i].Count > profile[j].Count
+		}
+		return profile[i].Subject < profile[j].Subject
+	})
+
+	b.profileMu.Lock()
+	b.subjectProfile = profile
+	b.profileFavorites = fingerprint
+	b.profileMu.Unlock()
+
+	return append([]SubjectWeight{}, profile...), nil
+}
+
+// favoriteSubjects finds a favorite on Open Library and returns its distinct, meaningful subjects
+func (b *Books) favoriteSubjects(ctx context.Context, favorite session.Book) []string {
+	resp, err := b.olClient.SearchBooks(ctx, fmt.Sprintf("%s %s", favorite.Title, favorite.Author))
+	if err != nil || len(resp.Docs) == 0 {
+		log.Printf("WARNING: Could not find favorite '%s' on Open Library: %v", favorite.Title, err)
+		return nil
+	}
+
+	best := resp.Docs[0]
+	bestSimilarity := 0.0
+	for _, doc := range resp.Docs {
+		if similarity := calculateSimilarity(doc.Title, favorite.Title); similarity > bestSimilarity {
+			bestSimilarity = similarity
+			best = doc
+		}
+	}
+
+	subjects := best.SubjectFacets
+	if len(subjects) == 0 && strings.HasPrefix(best.Key, "/works/") {
+		work, err := b.olClient.GetBookDetails(ctx, best.Key)
+		if err == nil {
+			subjects = work.Subjects
+		}
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		normalized := strings.ToLower(strings.TrimSpace(subject))
+		if normalized == "" || seen[normalized] || isNoiseSubject(normalized) {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+
+	return result
+}
+
+// isNoiseSubject filters out Open Library's cataloguing and lending tags, which say nothing about taste
+func isNoiseSubject(subject string) bool {
+	switch subject {
+	case "accessible book", "protected daisy", "in library", "lending library", "large type books",
+		"open library staff picks", "overdrive", "long now manual for civilization":
+		return true
+	}
+
+	return strings.Contains(subject, ":") || strings.Contains(subject, "=") ||
+		strings.HasPrefix(subject, "reading level")
+}
+
+// libraryItems returns the books the user already has: favorites, the reading list and past suggestions
+func (b *Books) libraryItems(sess *session.Session[session.Book]) []libraryItem {
+	items := suggestionHistory(sess)
+	for _, book := range append(b.centralManager.Favorites().GetBooks(), b.centralManager.Queue().GetBooks()...) {
+		items = append(items, libraryItem{title: book.Title, creator: book.Author})
+	}
+	return items
+}
+
+// GetDiscoverySuggestion suggests a book drawn from the Open Library catalog: candidates are pulled
+// from the subjects in the user's profile and the LLM picks the best fit among them
+func (b *Books) GetDiscoverySuggestion(requestContext session.RequestContext) (map[string]interface{}, error) {
+	ctx := context.Background()
+
+	requestContext, err := checkRequestContext(requestContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.pipeline.prefetch.exclusive(func() (map[string]interface{}, error) {
+		return b.discover(ctx, requestContext)
+	})
+}
+
+// discover asks the LLM to pick from works under the user's top subjects and records the pick
+func (b *Books) discover(ctx context.Context, requestContext session.RequestContext) (map[string]interface{}, error) {
+	sess := b.manager.GetOrCreateSession(ctx, b.manager.Key(), b.taskFunc, b.baselineFunc)
+
+	llmClient, ok := b.llmClients.current()
+	if !ok {
+		return nil, fmt.Errorf("no LLM clients are available, please check your API keys in settings")
+	}
+
+	profile, err := b.GetSubjectProfile()
+	if err != nil {
+		return nil, err
+	}
+	if len(profile) == 0 {
+		return nil, fmt.Errorf("no subjects found for your favorite books, add some favorites first")
+	}
+
+	subjects := make([]string, 0, maxProfileSubjects)
+	for _, weight := range profile {
+		if len(subjects) == maxProfileSubjects {
+			break
+		}
+		subjects = append(subjects, weight.Subject)
+	}
+
+	candidates := b.discoveryCandidates(ctx, sess, subjects)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no new books found for subjects: %s", strings.Join(subjects, ", "))
+	}
+
+	candidateBooks := make([]session.Book, len(candidates))
+	for i, c := range candidates {
+		candidateBooks[i] = c.book
+	}
+
+	// Candidates are passed as ephemeral constraints so they aren't persisted with the session
+	content := withNotes(profiledContent(ctx, session.BookMedia, b.manager.Snapshot(sess).Content), requestNotes(ctx, "book", requestContext,
+		b.centralManager.Settings().GetNovelty(session.BookMedia),
+		directives.GetBookCandidatesContext(ctx, subjects, candidateBooks))...)
+
+	var suggestion *llm.SuggestionResponse[session.Book]
+	var chosen *bookCandidate
+
+	for attempt := 1; ; attempt++ {
+		messages, err := llmClient.ComposeMessages(ctx, &content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose message for LLM: %w", err)
+		}
+
+		suggestion, err = llmClient.SendMessages(ctx, messages...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get suggestion from LLM: %w", err)
+		}
+
+		title := suggestion.Content.Title
+		if title == "" {
+			title = suggestion.Title
+		}
+
+		chosen = matchCandidate(candidates, title, suggestion.Content.Author)
+		if chosen != nil {
+			break
+		}
+
+		if attempt >= maxDiscoveryAttempts {
+			return nil, fmt.Errorf("LLM suggested '%s', which is not one of the catalog candidates", title)
+		}
+
+		log.Printf("Discovery suggestion '%s' was not one of the candidates, asking again", title)
+		content.UserConstraints = append(content.UserConstraints,
+			fmt.Sprintf("'%s' is not one of the catalog candidates. Only suggest a book from the candidate list.", title))
+	}
+
+	description := suggestion.Reason
+	if work, err := b.olClient.GetBookDetails(ctx, chosen.key); err == nil && work != nil {
+		switch desc := work.Description.(type) {
+		case string:
+			description = desc
+		case map[string]interface{}:
+			if val, ok := desc["value"].(string); ok {
+				description = val
+			}
+		}
 	}
 
-	// Store the suggestion in the session
 	bookSuggestion := session.Suggestion[session.Book]{
 		PrimaryGenre: suggestion.PrimaryGenre,
 		UserOutcome:  session.Pending,
 		Reasoning:    suggestion.Reason,
-		Content: session.Book{
-			Title:     bestMatch.Title,
-			Author:    bestMatch.Author,
-			CoverPath: bestMatch.CoverPath,
-		},
+		Year:         chosen.year,
+		Context:      storedContext(requestContext),
+		Content:      chosen.book,
 	}
 
 	if err := b.manager.AddSuggestion(ctx, sess, bookSuggestion); err != nil {
@@ -509,20 +902,130 @@ func (b *Books) GetBookSuggestion() (map[string]interface{}, error) {
 		return nil, fmt.Errorf("failed to add suggestion: %w", err)
 	}
 
-	// Return the book information
 	return map[string]interface{}{
-		"title":         bestMatch.Title,
-		"author":        bestMatch.Author,
-		"cover_path":    bestMatch.CoverPath,
+		"title":         chosen.book.Title,
+		"author":        chosen.book.Author,
+		"cover_path":    chosen.book.CoverPath,
 		"reasoning":     suggestion.Reason,
 		"primary_genre": suggestion.PrimaryGenre,
-		"key":           bestMatch.Key,
-		"description":   bestMatch.Description,
+		"key":           chosen.key,
+		"description":   description,
+		"subjects":      subjects,
 	}, nil
 }
 
+// discoveryCandidates pulls works for each subject, skipping anything the user already knows about or
+// their feedback leans against, and interleaves them so no single subject dominates the list
+func (b *Books) discoveryCandidates(ctx context.Context, sess *session.Session[session.Book], subjects []string) []bookCandidate {
+	known := make(map[string]bool)
+	for _, book := range b.centralManager.Favorites().GetBooks() {
+		known[strings.ToLower(book.Title+"|"+book.Author)] = true
+	}
+	for _, book := range b.centralManager.Queue().GetBooks() {
+		known[strings.ToLower(book.Title+"|"+book.Author)] = true
+	}
+	for _, s := range sess.Suggestions {
+		known[strings.ToLower(s.Content.Title+"|"+s.Content.Author)] = true
+	}
+
+	ranker := ranking.NewRanker(sess.Suggestions, ranking.DefaultThreshold)
+	seen := make(map[string]bool)
+	perSubject := make([][]bookCandidate, 0, len(subjects))
+	for _, subject := range subjects {
+		resp, err := b.olClient.GetSubject(ctx, subject, subjectWorksLimit)
+		if err != nil {
+			log.Printf("WARNING: Failed to get works for subject '%s': %v", subject, err)
+			continue
+		}
+
+		var list []bookCandidate
+		for _, work := range resp.Works {
+			if len(work.Authors) == 0 || seen[work.Key] {
+				continue
+			}
+			book := session.Book{
+				Title:     work.Title,
+				Author:    work.Authors[0].Name,
+				CoverPath: b.olClient.GetCoverURL(work.CoverID),
+			}
+			if known[strings.ToLower(book.Title+"|"+book.Author)] {
+				continue
+			}
+			seen[work.Key] = true
+			list = append(list, bookCandidate{
+				book:     book,
+				key:      work.Key,
+				subjects: append([]string{subject}, work.Subject...),
+				year:     work.FirstPublishYear,
+			})
+		}
+		perSubject = append(perSubject, rankCandidates(ranker, list))
+	}
+
+	var candidates []bookCandidate
+	for i := 0; len(candidates) < maxDiscoveryCandidates; i++ {
+		added := false
+		for _, list := range perSubject {
+			if i < len(list) && len(candidates) < maxDiscoveryCandidates {
+				candidates = append(candidates, list[i])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	return candidates
+}
+
+// rankCandidates drops the candidates the user's feedback leans against and orders the rest best first
+func rankCandidates(ranker *ranking.Ranker[session.Book], list []bookCandidate) []bookCandidate {
+	byKey := make(map[string]bookCandidate, len(list))
+	toRank := make([]ranking.Candidate[session.Book], len(list))
+	for i, c := range list {
+		byKey[c.book.Key()] = c
+		toRank[i] = ranking.Candidate[session.Book]{Content: c.book, Genres: c.subjects, Year: c.year}
+	}
+
+	ranked := ranker.Rank(toRank)
+	result := make([]bookCandidate, len(ranked))
+	for i, r := range ranked {
+		result[i] = byKey[r.Content.Key()]
+	}
+
+	return result
+}
+
+// matchCandidate finds the candidate the LLM meant, tolerating small differences in punctuation or casing
+func matchCandidate(candidates []bookCandidate, title, author string) *bookCandidate {
+	var best *bookCandidate
+	bestSimilarity := 0.0
+
+	for i := range candidates {
+		similarity := calculateSimilarity(candidates[i].book.Title, title)
+		if author != "" {
+			similarity = similarity*0.7 + calculateSimilarity(candidates[i].book.Author, author)*0.3
+		}
+		if similarity > bestSimilarity {
+			bestSimilarity = similarity
+			best = &candidates[i]
+		}
+	}
+
+	if bestSimilarity < 0.8 {
+		return nil
+	}
+
+	return best
+}
+
 // ProvideSuggestionFeedback provides feedback on a suggestion
 func (b *Books) ProvideSuggestionFeedback(outcome session.Outcome, title string, author string) error {
+	// Suggestions fetched ahead were asked for without this feedback
+	resume := b.pipeline.prefetch.invalidate()
+	defer resume()
+
 	ctx := context.Background()
 	sess := b.manager.GetOrCreateSession(ctx, b.manager.Key(), b.taskFunc, b.baselineFunc)
 
@@ -551,37 +1054,15 @@ func (b *Books) ProvideSuggestionFeedback(outcome session.Outcome, title string,
 	return nil
 }
 
+// invalidatePrefetched implements Prefetching
+func (b *Books) invalidatePrefetched() func() {
+	return b.pipeline.prefetch.invalidate()
+}
+
 // RefreshLLMClients attempts to recreate LLM clients that may have failed to initialize
 func (b *Books) RefreshLLMClients() {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	log.Println("Refreshing Books LLM clients")
+	resume := b.pipeline.prefetch.invalidate()
+	defer resume()
 
-	// Check if OpenAI client is missing
-	if _, ok := b.llmClients["openai"]; !ok {
-		openaiClient, err := openai.NewClient[session.Book](b.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-		} else {
-			b.llmClients["openai"] = openaiClient
-			log.Println("Successfully created OpenAI client for Books")
-		}
-	}
-
-	// Check if Gemini client is missing
-	if _, ok := b.llmClients["gemini"]; !ok {
-		geminiClient, err := gemini.NewClient[session.Book](b.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create Gemini client: %v", err)
-		} else {
-			b.llmClients["gemini"] = geminiClient
-			log.Println("Successfully created Gemini client for Books")
-		}
-	}
-
-	// Log warning if still no clients instead of returning error
-	if len(b.llmClients) == 0 {
-		log.Printf("WARNING: Could not create any LLM clients after refresh, functionality may be limited")
-	}
+	b.llmClients.refresh("Books")
 }
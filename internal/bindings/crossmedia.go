@@ -0,0 +1,139 @@
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"interestnaut/internal/directives"
+	"interestnaut/internal/session"
+	"interestnaut/internal/spotify"
+	"log"
+	"sort"
+)
+
+// maxCrossMediaItems caps each list taken from a source so several sources still fit in one prompt
+const maxCrossMediaItems = 25
+
+// mediaNames are how each kind of media is referred to in prompts
+var mediaNames = map[session.MediaType]string{
+	session.MusicMedia:     "music",
+	session.MovieMedia:     "movies",
+	session.BookMedia:      "books",
+	session.TVMedia:        "TV shows",
+	session.VideoGameMedia: "video games",
+}
+
+// crossMediaNote returns the constraint carrying the user's taste in the source media over to the target,
+// built from each source's favorites and the outcomes of its past suggestions
+func crossMediaNote(
+	ctx context.Context,
+	cm session.CentralManager,
+	target session.MediaType,
+	sources []session.MediaType,
+) (string, error) {
+	if len(sources) == 0 {
+		return "", fmt.Errorf("choose at least one other kind of media to base the suggestion on")
+	}
+
+	seen := make(map[session.MediaType]bool)
+	histories := make([]directives.MediaHistory, 0, len(sources))
+	for _, source := range sources {
+		if _, ok := mediaNames[source]; !ok {
+			return "", fmt.Errorf("unknown media type: %s", source)
+		}
+		if source == target {
+			return "", fmt.Errorf("a cross-media suggestion can't be based on %s itself", mediaNames[target])
+		}
+		if seen[source] {
+			continue
+		}
+		seen[source] = true
+
+		histories = append(histories, mediaHistory(ctx, cm, source))
+	}
+
+	note := directives.GetCrossMediaContext(ctx, mediaNames[target], histories)
+	if note == "" {
+		return "", fmt.Errorf("there are no favorites or rated suggestions to base the suggestion on yet")
+	}
+
+	return note, nil
+}
+
+// mediaHistory collects the favorites and rated suggestions for one kind of media
+func mediaHistory(ctx context.Context, cm session.CentralManager, mediaType session.MediaType) directives.MediaHistory {
+	history := directives.MediaHistory{Media: mediaNames[mediaType]}
+
+	switch mediaType {
+	case session.MusicMedia:
+		history.Liked, history.Disliked = ratedSuggestions(ctx, cm.Music())
+		if dataDir, err := session.DataDir(); err != nil {
+			log.Printf("WARNING: Failed to get data directory for the Spotify library snapshot: %v", err)
+		} else {
+			for _, saved := range spotify.NewLibrary(spotifyLibraryFile(dataDir)).Tracks() {
+				if saved.Track == nil {
+					continue
+				}
+				track := spotify.ToSimpleTrack(saved.Track)
+				history.Favorites = append(history.Favorites, libraryItem{title: track.Name, creator: track.Artist}.String())
+			}
+		}
+	case session.MovieMedia:
+		history.Liked, history.Disliked = ratedSuggestions(ctx, cm.Movie())
+		for _, movie := range cm.Favorites().GetMovies() {
+			history.Favorites = append(history.Favorites, libraryItem{title: movie.Title, creator: movie.Director}.String())
+		}
+	case session.BookMedia:
+		history.Liked, history.Disliked = ratedSuggestions(ctx, cm.Book())
+		for _, book := range cm.Favorites().GetBooks() {
+			history.Favorites = append(history.Favorites, libraryItem{title: book.Title, creator: book.Author}.String())
+		}
+	case session.TVMedia:
+		history.Liked, history.Disliked = ratedSuggestions(ctx, cm.TVShow())
+		for _, show := range cm.Favorites().GetTVShows() {
+			history.Favorites = append(history.Favorites, libraryItem{title: show.Title, creator: show.Director}.String())
+		}
+	case session.VideoGameMedia:
+		history.Liked, history.Disliked = ratedSuggestions(ctx, cm.VideoGame())
+		for _, game := range cm.Favorites().GetVideoGames() {
+			history.Favorites = append(history.Favorites, libraryItem{title: game.Title, creator: game.Developer}.String())
+		}
+	}
+
+	history.Favorites = capItems(history.Favorites)
+	return history
+}
+
+// ratedSuggestions returns descriptions of the session's liked and disliked suggestions, most recent first
+func ratedSuggestions[T session.Media](ctx context.Context, manager session.Manager[T]) ([]string, []string) {
+	sess, err := manager.GetSession(ctx, manager.Key())
+	if err != nil {
+		return nil, nil
+	}
+
+	suggestions := make([]session.Suggestion[T], 0, len(sess.Suggestions))
+	for _, s := range sess.Suggestions {
+		suggestions = append(suggestions, s)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].SuggestedAt > suggestions[j].SuggestedAt
+	})
+
+	var liked, disliked []string
+	for _, s := range suggestions {
+		switch s.UserOutcome {
+		case session.Liked, session.Added:
+			liked = append(liked, describeSuggestion(s))
+		case session.Disliked:
+			disliked = append(disliked, describeSuggestion(s))
+		}
+	}
+
+	return capItems(liked), capItems(disliked)
+}
+
+func capItems(items []string) []string {
+	if len(items) > maxCrossMediaItems {
+		return items[:maxCrossMediaItems]
+	}
+	return items
+}
@@ -5,15 +5,15 @@ import (
 	"fmt"
 	"html"
 	"interestnaut/internal/directives"
-	"interestnaut/internal/gemini"
 	"interestnaut/internal/llm"
-	"interestnaut/internal/openai"
 	"interestnaut/internal/rawg"
 	"interestnaut/internal/session"
 	"log"
+	"math"
 	"regexp"
+	"sort"
 	"strings"
-	"sync"
+	"time"
 )
 
 type Screenshot struct {
@@ -55,11 +55,12 @@ type Publisher struct {
 // Games provides bindings for the RAWG API client
 type Games struct {
 	client                 rawg.Client
-	llmClients             map[string]llm.Client[session.VideoGame]
+	llmClients             *llmClients[session.VideoGame]
+	recommender            *Recommender[session.VideoGame, *GameWithSavedStatus, map[string]interface{}]
 	manager                session.Manager[session.VideoGame]
 	centralManager         session.CentralManager
 	baselineFunc, taskFunc func() string
-	mu                     sync.Mutex
+	pipeline               *pipeline[session.VideoGame, map[string]interface{}]
 }
 
 // GameWithSavedStatus represents a game with additional saved status flags
@@ -189,31 +190,11 @@ func createBasicGame(title string, description string, primaryGenre string) *Gam
 func NewGames(ctx context.Context, cm session.CentralManager) (*Games, error) {
 	client := rawg.NewClient()
 
-	llmClients := make(map[string]llm.Client[session.VideoGame])
-
-	openaiClient, err := openai.NewClient[session.VideoGame](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-	} else {
-		llmClients["openai"] = openaiClient
-	}
-
-	geminiClient, err := gemini.NewClient[session.VideoGame](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create Gemini client: %v", err)
-	} else {
-		llmClients["gemini"] = geminiClient
-	}
-
-	if len(llmClients) == 0 {
-		log.Printf("WARNING: No LLM clients available, functionality may be limited")
-	}
-
 	manager := cm.VideoGame()
 
 	g := &Games{
 		client:         client,
-		llmClients:     llmClients,
+		llmClients:     newLLMClients[session.VideoGame](cm),
 		manager:        manager,
 		centralManager: cm,
 	}
@@ -227,6 +208,56 @@ func NewGames(ctx context.Context, cm session.CentralManager) (*Games, error) {
 		return directives.GetGameBaseline(ctx, favorites)
 	}
 
+	g.recommender = &Recommender[session.VideoGame, *GameWithSavedStatus, map[string]interface{}]{
+		kind:      "game",
+		media:     session.VideoGameMedia,
+		cm:        cm,
+		manager:   manager,
+		clients:   g.llmClients,
+		resolve:   g.resolveOnOwnedPlatforms,
+		mapResult: mapSuggestedGame,
+		fallback: func() map[string]interface{} {
+			return map[string]interface{}{
+				"game":   createBasicGame("LLM Suggestion Unavailable", "LLM services are currently unavailable. Please ensure your API keys are correctly configured.", "Not Available"),
+				"reason": "No LLM clients are available. Please check your API keys in settings.",
+			}
+		},
+		library: g.libraryItems,
+		details: func(s *llm.SuggestionResponse[session.VideoGame], game *GameWithSavedStatus) (matchDetails, matchDetails) {
+			return suggestedGameDetails(s), foundGameDetails(game)
+		},
+		// Owned platforms and the backlog are passed as ephemeral constraints so they always reflect
+		// current settings
+		notes: func(ctx context.Context) []string {
+			var notes []string
+			if note := directives.GetGamePlatformContext(ctx, cm.Settings().GetOwnedPlatforms()); note != "" {
+				notes = append(notes, note)
+			}
+			if note := directives.GetGameBacklogContext(ctx, cm.Queue().GetGameBacklog()); note != "" {
+				notes = append(notes, note)
+			}
+			return notes
+		},
+		popularity: func(game *GameWithSavedStatus) (string, float64) {
+			return game.Name, float64(game.RatingsCount)
+		},
+		scale: rawgRatings,
+	}
+
+	g.pipeline = newPipeline(&pipeline[session.VideoGame, map[string]interface{}]{
+		media:   session.VideoGameMedia,
+		cm:      cm,
+		manager: g.manager,
+		clients: g.llmClients,
+		session: func(ctx context.Context) (*session.Session[session.VideoGame], string) {
+			return g.manager.GetOrCreateSession(ctx, g.manager.Key(), g.taskFunc, g.baselineFunc), "game"
+		},
+		prepare: g.prepare,
+		state: func() []string {
+			return []string{fmt.Sprint(cm.Settings().GetOwnedPlatforms()), fmt.Sprint(cm.Queue().GetGameBacklog())}
+		},
+	})
+
 	return g, nil
 }
 
@@ -284,9 +315,44 @@ func (g *Games) AddToWatchlist(game session.VideoGame) error {
 	}
 
 	log.Printf("Added '%s' to watchlist", game.Title)
+
+	// Best effort, the backlog works without a playtime estimate
+	if playtime := g.lookupPlaytime(game.Title); playtime > 0 {
+		for _, entry := range g.centralManager.Queue().GetGameBacklog() {
+			if entry.Game.Equal(game) {
+				entry.Playtime = playtime
+				if err := g.centralManager.Queue().SetBacklogEntry(entry); err != nil {
+					log.Printf("WARNING: Failed to record playtime for '%s': %v", game.Title, err)
+				}
+				break
+			}
+		}
+	}
+
 	return nil
 }
 
+// lookupPlaytime returns RAWG's average playtime in hours for an exact title match, or 0
+func (g *Games) lookupPlaytime(title string) int {
+	if !g.client.HasValidCredentials() {
+		return 0
+	}
+
+	resp, err := g.client.SearchGames(context.Background(), title, 1, 5)
+	if err != nil {
+		log.Printf("WARNING: Failed to look up playtime for '%s': %v", title, err)
+		return 0
+	}
+
+	for _, result := range resp.Results {
+		if strings.EqualFold(result.Name, title) {
+			return result.Playtime
+		}
+	}
+
+	return 0
+}
+
 // RemoveFromWatchlist removes a game from the watchlist
 func (g *Games) RemoveFromWatchlist(title string) error {
 	// Get current watchlist
@@ -322,6 +388,110 @@ func (g *Games) GetWatchlist() ([]session.VideoGame, error) {
 	return g.centralManager.Queue().GetVideoGames(), nil
 }
 
+// GetBacklog returns the queued games along with their playtime, priority and status
+func (g *Games) GetBacklog() ([]session.BacklogEntry, error) {
+	return g.centralManager.Queue().GetGameBacklog(), nil
+}
+
+// UpdateBacklogEntry sets the status, priority and playtime (in hours) of a queued game,
+// stamping the start and finish dates as the status moves along
+func (g *Games) UpdateBacklogEntry(title string, status session.BacklogStatus, priority, playtime int) (*session.BacklogEntry, error) {
+	switch status {
+	case session.Unplayed, session.Playing, session.Beaten, session.Abandoned:
+	default:
+		return nil, fmt.Errorf("invalid backlog status: %s", status)
+	}
+
+	if playtime < 0 {
+		return nil, fmt.Errorf("playtime cannot be negative")
+	}
+
+	var entry *session.BacklogEntry
+	for _, e := range g.centralManager.Queue().GetGameBacklog() {
+		if e.Game.Title == title {
+			entry = &e
+			break
+		}
+	}
+
+	if entry == nil {
+		return nil, fmt.Errorf("game '%s' not found in watchlist", title)
+	}
+
+	now := time.Now().Unix()
+	switch status {
+	case session.Unplayed:
+		entry.StartedAt, entry.FinishedAt = 0, 0
+	case session.Playing:
+		if entry.StartedAt == 0 {
+			entry.StartedAt = now
+		}
+		entry.FinishedAt = 0
+	case session.Beaten, session.Abandoned:
+		if entry.StartedAt == 0 {
+			entry.StartedAt = now
+		}
+		if entry.Status != status || entry.FinishedAt == 0 {
+			entry.FinishedAt = now
+		}
+	}
+
+	entry.Status = status
+	entry.Priority = priority
+	entry.Playtime = playtime
+
+	if err := g.centralManager.Queue().SetBacklogEntry(*entry); err != nil {
+		return nil, fmt.Errorf("failed to update backlog: %w", err)
+	}
+
+	return entry, nil
+}
+
+// PlayNextPick is a backlog game along with how long it should take at the user's pace
+type PlayNextPick struct {
+	Entry session.BacklogEntry `json:"entry"`
+	// Weeks is the estimated number of weeks to finish, or 0 when RAWG has no playtime for the game
+	Weeks float64 `json:"weeks"`
+}
+
+// GetPlayNext orders the unfinished backlog by what to play next for someone with hoursPerWeek to spare;
+// games in progress come first, then priority, then whatever can be finished soonest
+func (g *Games) GetPlayNext(hoursPerWeek int) ([]PlayNextPick, error) {
+	if hoursPerWeek <= 0 {
+		return nil, fmt.Errorf("hours per week must be positive")
+	}
+
+	picks := make([]PlayNextPick, 0)
+	for _, entry := range g.centralManager.Queue().GetGameBacklog() {
+		if entry.Status != session.Unplayed && entry.Status != session.Playing {
+			continue
+		}
+
+		pick := PlayNextPick{Entry: entry}
+		if entry.Playtime > 0 {
+			pick.Weeks = math.Ceil(float64(entry.Playtime)/float64(hoursPerWeek)*10) / 10
+		}
+		picks = append(picks, pick)
+	}
+
+	sort.SliceStable(picks, func(i, j int) bool {
+		a, b := picks[i], picks[j]
+		if (a.Entry.Status == session.Playing) != (b.Entry.Status == session.Playing) {
+			return a.Entry.Status == session.Playing
+		}
+		if a.Entry.Priority != b.Entry.Priority {
+			return a.Entry.Priority > b.Entry.Priority
+		}
+		// Unknown playtimes sort last so known short games aren't buried
+		if (a.Weeks == 0) != (b.Weeks == 0) {
+			return b.Weeks == 0
+		}
+		return a.Weeks < b.Weeks
+	})
+
+	return picks, nil
+}
+
 // HasValidCredentials checks if the client has valid credentials
 func (g *Games) HasValidCredentials() bool {
 	return g.client.HasValidCredentials()
@@ -428,195 +598,252 @@ func (g *Games) GetGameDetails(id int) (*GameWithSavedStatus, error) {
 	return rawgGameToGameWithSavedStatus(game, isSaved, isInWatchlist), nil
 }
 
+// invalidatePrefetched implements Prefetching
+func (g *Games) invalidatePrefetched() func() {
+	return g.pipeline.prefetch.invalidate()
+}
+
 // RefreshLLMClients attempts to recreate LLM clients that may have failed to initialize
 func (g *Games) RefreshLLMClients() {
-	g.mu.Lock()
-	defer g.mu.Unlock()
+	resume := g.pipeline.prefetch.invalidate()
+	defer resume()
 
-	log.Println("Refreshing Games LLM clients")
+	g.llmClients.refresh("Games")
+}
 
-	// Check if OpenAI client is missing
-	if _, ok := g.llmClients["openai"]; !ok {
-		openaiClient, err := openai.NewClient[session.VideoGame](g.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-		} else {
-			g.llmClients["openai"] = openaiClient
-			log.Println("Successfully created OpenAI client for Games")
-		}
-	}
+// GetGameSuggestion gets a game suggestion from the LLM
+func (g *Games) GetGameSuggestion(requestContext session.RequestContext) (map[string]interface{}, error) {
+	return g.pipeline.next(requestContext)
+}
 
-	// Check if Gemini client is missing
-	if _, ok := g.llmClients["gemini"]; !ok {
-		geminiClient, err := gemini.NewClient[session.VideoGame](g.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create Gemini client: %v", err)
-		} else {
-			g.llmClients["gemini"] = geminiClient
-			log.Println("Successfully created Gemini client for Games")
-		}
-	}
+// RefineSuggestion asks for a follow-up to the latest suggestion, steered by the user's instruction,
+// e.g. "like this, but darker"
+func (g *Games) RefineSuggestion(instruction string) (map[string]interface{}, error) {
+	return g.pipeline.refine(instruction)
+}
 
-	// Log warning if still no clients instead of returning error
-	if len(g.llmClients) == 0 {
-		log.Printf("WARNING: Could not create any LLM clients after refresh, functionality may be limited")
-	}
+// GetCrossMediaSuggestion suggests based on the user's taste in other kinds of media, e.g. a book
+// based on their movies and games
+func (g *Games) GetCrossMediaSuggestion(
+	sources []session.MediaType,
+	requestContext session.RequestContext,
+) (map[string]interface{}, error) {
+	return g.pipeline.crossMedia(sources, requestContext)
 }
 
-// GetGameSuggestion gets a game suggestion from the LLM
-func (g *Games) GetGameSuggestion() (map[string]interface{}, error) {
-	ctx := context.Background()
+// GetTasteProfile returns the LLM's summary of the user's game taste, generating or refreshing it first
+func (g *Games) GetTasteProfile() (*session.TasteProfile, error) {
+	return g.pipeline.profile()
+}
+
+// UpdateTasteProfile replaces the game taste profile with the user's own version, which is kept when it's
+// next refreshed
+func (g *Games) UpdateTasteProfile(text string) (*session.TasteProfile, error) {
+	return g.pipeline.updateProfile(text)
+}
 
+// prepare gets and resolves a game suggestion in the session without recording it
+func (g *Games) prepare(
+	ctx context.Context,
+	sess *session.Session[session.VideoGame],
+	_ string,
+	requestContext session.RequestContext,
+	notes ...string,
+) (*pendingSuggestion[map[string]interface{}], error) {
 	if !g.client.HasValidCredentials() {
 		return nil, fmt.Errorf("RAWG credentials not available")
 	}
 
-	// Get or create a session
-	sess := g.manager.GetOrCreateSession(ctx, g.manager.Key(), g.taskFunc, g.baselineFunc)
-
-	// Get current LLM provider from settings
-	provider := g.centralManager.Settings().GetLLMProvider()
-
-	// Get the appropriate client
-	llmClient, ok := g.llmClients[provider]
-	if !ok {
-		// Fall back to openai if the requested provider is not available
-		log.Printf("WARNING: Requested LLM provider '%s' not available, falling back to openai", provider)
-		llmClient, ok = g.llmClients["openai"]
-		if !ok {
-			log.Printf("WARNING: No LLM clients available, providing a default suggestion")
-			// Create a fallback game suggestion
-			game := createBasicGame("LLM Suggestion Unavailable", "LLM services are currently unavailable. Please ensure your API keys are correctly configured.", "Not Available")
+	return g.recommender.recommend(ctx, sess, requestContext, notes...)
+}
 
-			result := map[string]interface{}{
-				"game":   game,
-				"reason": "No LLM clients are available. Please check your API keys in settings.",
-			}
-			return result, nil
-		}
+// resolveOnOwnedPlatforms resolves a suggested game on RAWG, missing when it can't be found or can't be
+// played on any of the platforms the user owns
+func (g *Games) resolveOnOwnedPlatforms(
+	ctx context.Context,
+	suggestion *llm.SuggestionResponse[session.VideoGame],
+) (*GameWithSavedStatus, error) {
+	owned := g.centralManager.Settings().GetOwnedPlatforms()
+	platformIDs := make([]int, len(owned))
+	for i, p := range owned {
+		platformIDs[i] = p.ID
 	}
 
-	// Compose messages from the session content
-	messages, err := llmClient.ComposeMessages(ctx, &sess.Content)
+	game, available, err := g.resolveSuggestedGame(ctx, suggestion, platformIDs)
 	if err != nil {
-		log.Printf("ERROR: Failed to compose messages for game suggestion: %v", err)
-		return nil, fmt.Errorf("failed to compose messages for game suggestion: %w", err)
+		return nil, err
+	}
+	if game == nil {
+		return nil, missed("'%s' could not be found on RAWG. Suggest a different game.", suggestion.Title)
+	}
+	if !available {
+		return nil, missed("'%s' is not available on any platform the user owns. Suggest a different game.", suggestion.Title)
 	}
+	return game, nil
+}
 
-	// Request a suggestion from the LLM
-	suggestion, err := llmClient.SendMessages(ctx, messages...)
-	if err != nil {
-		log.Printf("ERROR: Failed to get game suggestion: %v", err)
-		return nil, fmt.Errorf("failed to get game suggestion: %w", err)
+// mapSuggestedGame records the game as RAWG knows it, keeping the LLM's developer and publisher
+func mapSuggestedGame(
+	suggestion *llm.SuggestionResponse[session.VideoGame],
+	game *GameWithSavedStatus,
+) (session.Suggestion[session.VideoGame], map[string]interface{}) {
+	recorded := session.Suggestion[session.VideoGame]{
+		Year: yearOf(game.Released),
+		Content: session.VideoGame{
+			Title:     game.Name,
+			Developer: suggestion.Content.Developer,
+			Publisher: suggestion.Content.Publisher,
+			Platforms: platformNames(game.Platforms),
+			// Use CoverPath instead of PosterPath for games
+			CoverPath: game.BackgroundImage,
+		},
 	}
 
-	if suggestion == nil {
-		return nil, fmt.Errorf("no suggestion available")
+	// Return a map that can be easily serialized to JSON
+	return recorded, map[string]interface{}{
+		"game":   game,
+		"reason": suggestion.Reason,
 	}
+}
 
+// resolveSuggestedGame looks the LLM's suggestion up on RAWG, restricted to the given platforms when there are any,
+// taking the result closest to its title and year. The game is nil when nothing was found, and the returned bool
+// reports whether it's playable on those platforms, which it always is when none are set
+func (g *Games) resolveSuggestedGame(
+	ctx context.Context,
+	suggestion *llm.SuggestionResponse[session.VideoGame],
+	platformIDs []int,
+) (*GameWithSavedStatus, bool, error) {
 	// Try to find more details about the suggested game from RAWG
 	query := suggestion.Title
-	resp, err := g.client.SearchGames(ctx, query, 1, 10)
+	resp, err := g.client.SearchGamesOnPlatforms(ctx, query, platformIDs, 1, 10)
 	if err != nil {
-		log.Printf("WARNING: Failed to search for suggested game '%s': %v", query, err)
-	}
-
-	var game *GameWithSavedStatus
-	var foundExactMatch bool
-	var detailedGame *rawg.Game
-
-	if err == nil && len(resp.Results) > 0 {
-		// Use the first result that matches closely enough
-		for _, result := range resp.Results {
-			if strings.EqualFold(result.Name, suggestion.Title) {
-				// We found an exact match, now get the detailed game info
-				foundExactMatch = true
-				detailedGame, err = g.client.GetGameDetails(ctx, result.ID)
-				if err != nil {
-					log.Printf("WARNING: Failed to get detailed info for game '%s' (ID: %d): %v", result.Name, result.ID, err)
-					// Fall back to using search result
-					detailedGame = &result
-				}
-				break
-			}
+		log.Printf("ERROR: Failed to search for suggested game '%s': %v", query, err)
+		return nil, false, fmt.Errorf("failed to search for suggested game '%s': %w", query, err)
+	}
+	if len(resp.Results) == 0 {
+		return nil, false, nil
+	}
+
+	result := bestMatch(suggestedGameDetails(suggestion), resp.Results, func(result rawg.Game) matchDetails {
+		return matchDetails{title: result.Name, year: yearOf(result.Released)}
+	})
+
+	// Get the detailed game info, which has the developers it's verified against
+	detailedGame, err := g.client.GetGameDetails(ctx, result.ID)
+	if err != nil {
+		log.Printf("WARNING: Failed to get detailed info for game '%s' (ID: %d): %v", result.Name, result.ID, err)
+		// Fall back to using search result
+		detailedGame = &result
+	}
+
+	// Check if this game is saved in favorites
+	favorites := g.centralManager.Favorites().GetVideoGames()
+	isSaved := false
+	for _, favorite := range favorites {
+		if strings.EqualFold(favorite.Title, detailedGame.Name) {
+			isSaved = true
+			break
 		}
+	}
 
-		// If no exact match, use the first result and get details
-		if !foundExactMatch && len(resp.Results) > 0 {
-			detailedGame, err = g.client.GetGameDetails(ctx, resp.Results[0].ID)
-			if err != nil {
-				log.Printf("WARNING: Failed to get detailed info for game '%s' (ID: %d): %v", resp.Results[0].Name, resp.Results[0].ID, err)
-				// Fall back to using search result
-				detailedGame = &resp.Results[0]
-			}
+	// Check if in watchlist
+	watchlist := g.centralManager.Queue().GetVideoGames()
+	isInWatchlist := false
+	for _, item := range watchlist {
+		if strings.EqualFold(item.Title, detailedGame.Name) {
+			isInWatchlist = true
+			break
 		}
+	}
 
-		if detailedGame != nil {
-			// Check if this game is saved in favorites
-			favorites := g.centralManager.Favorites().GetVideoGames()
-			isSaved := false
-			for _, favorite := range favorites {
-				if strings.EqualFold(favorite.Title, detailedGame.Name) {
-					isSaved = true
-					break
-				}
-			}
+	game := rawgGameToGameWithSavedStatus(detailedGame, isSaved, isInWatchlist)
+	return game, len(platformIDs) == 0 || availableOnPlatforms(detailedGame, platformIDs), nil
+}
 
-			// Check if in watchlist
-			watchlist := g.centralManager.Queue().GetVideoGames()
-			isInWatchlist := false
-			for _, item := range watchlist {
-				if strings.EqualFold(item.Title, detailedGame.Name) {
-					isInWatchlist = true
-					break
-				}
-			}
+// suggestedGameDetails is what a game suggestion is verified on
+func suggestedGameDetails(suggestion *llm.SuggestionResponse[session.VideoGame]) matchDetails {
+	return matchDetails{
+		title:    suggestion.Title,
+		year:     yearOf(string(suggestion.Year)),
+		creators: nonEmpty(suggestion.Content.Developer),
+	}
+}
 
-			game = rawgGameToGameWithSavedStatus(detailedGame, isSaved, isInWatchlist)
-		}
+// foundGameDetails is what RAWG knows about a game to verify a suggestion against
+func foundGameDetails(game *GameWithSavedStatus) matchDetails {
+	developers := make([]string, len(game.Developers))
+	for i, d := range game.Developers {
+		developers[i] = d.Name
 	}
+	return matchDetails{title: game.Name, year: yearOf(game.Released), creators: developers}
+}
 
-	// If we didn't find anything in RAWG, create a basic game object with the suggestion data
-	if game == nil {
-		// Create a basic game with the suggestion data
-		description := fmt.Sprintf("Developed by %s, published by %s. %s",
-			suggestion.Content.Developer,
-			suggestion.Content.Publisher,
-			suggestion.PrimaryGenre)
+// availableOnPlatforms reports whether RAWG lists the game on any of the given platforms
+func availableOnPlatforms(game *rawg.Game, platformIDs []int) bool {
+	for _, p := range game.Platforms {
+		for _, id := range platformIDs {
+			if p.Platform.ID == id || (p.Platform.ID == 0 && p.ID == id) {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-		game = createBasicGame(suggestion.Title, description, suggestion.PrimaryGenre)
+// platformNames flattens RAWG platform data into display names
+func platformNames(platforms []Platform) []string {
+	names := make([]string, 0, len(platforms))
+	for _, p := range platforms {
+		name := p.Platform.Name
+		if name == "" {
+			name = p.Name
+		}
+		if name != "" {
+			names = append(names, name)
+		}
 	}
+	return names
+}
 
-	// Create a session suggestion to record it
-	sessionSuggestion := session.Suggestion[session.VideoGame]{
-		PrimaryGenre: suggestion.PrimaryGenre,
-		UserOutcome:  session.Pending,
-		Reasoning:    suggestion.Reason,
-		Content: session.VideoGame{
-			Title:     game.Name,
-			Developer: suggestion.Content.Developer,
-			Publisher: suggestion.Content.Publisher,
-			// Use CoverPath instead of PosterPath for games
-			CoverPath: game.BackgroundImage,
-		},
+// GetAvailablePlatforms returns every platform known to RAWG, for choosing owned platforms
+func (g *Games) GetAvailablePlatforms() ([]session.GamePlatform, error) {
+	if !g.client.HasValidCredentials() {
+		return nil, fmt.Errorf("RAWG credentials not available")
 	}
 
-	if sErr := g.manager.AddSuggestion(ctx, sess, sessionSuggestion); sErr != nil {
-		log.Printf("ERROR: Failed to add suggestion: %v", sErr)
-		return nil, fmt.Errorf("failed to add suggestion: %w", sErr)
+	platforms, err := g.client.GetPlatforms(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platforms: %w", err)
 	}
 
-	// Return a map that can be easily serialized to JSON
-	result := map[string]interface{}{
-		"game":   game,
-		"reason": suggestion.Reason,
+	result := make([]session.GamePlatform, len(platforms))
+	for i, p := range platforms {
+		result[i] = session.GamePlatform{
+			ID:   p.ID,
+			Name: p.Name,
+			Slug: p.Slug,
+		}
 	}
 
 	return result, nil
 }
 
+// libraryItems returns the games the user already has: favorites, the backlog and past suggestions
+func (g *Games) libraryItems(sess *session.Session[session.VideoGame]) []libraryItem {
+	items := suggestionHistory(sess)
+	for _, game := range append(g.centralManager.Favorites().GetVideoGames(), g.centralManager.Queue().GetVideoGames()...) {
+		items = append(items, libraryItem{title: game.Title, creator: game.Developer})
+	}
+	return items
+}
+
 // ProvideSuggestionFeedback provides feedback on a suggestion
 func (g *Games) ProvideSuggestionFeedback(outcome session.Outcome, gameID int) error {
+	// Suggestions fetched ahead were asked for without this feedback
+	resume := g.pipeline.prefetch.invalidate()
+	defer resume()
+
 	// Get the current session
 	sess := g.manager.GetOrCreateSession(context.Background(), g.manager.Key(), g.taskFunc, g.baselineFunc)
 
@@ -673,10 +900,13 @@ func (g *Games) ProvideSuggestionFeedback(outcome session.Outcome, gameID int) e
 			Publisher: publisher,
 		}
 
-		// Add background image if available
+		// Add background image and platforms if available
 		if rawgGame != nil && rawgGame.BackgroundImage != "" {
 			favoriteGame.CoverPath = rawgGame.BackgroundImage
 		}
+		if rawgGame != nil {
+			favoriteGame.Platforms = platformNames(rawgGameToGameWithSavedStatus(rawgGame, false, false).Platforms)
+		}
 
 		// Add to favorites using the central manager
 		if err := g.centralManager.Favorites().AddVideoGame(favoriteGame); err != nil {
@@ -0,0 +1,191 @@
+package bindings
+
+import (
+	"fmt"
+	"interestnaut/internal/llm"
+	"interestnaut/internal/session"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// libraryTitleSimilarity is how close a title has to be to count as the same thing, loose enough for
+	// punctuation, spelling and article differences. Sequels are told apart by their numbers and subtitles.
+	libraryTitleSimilarity = 0.9
+	// libraryCreatorSimilarity is lower since names are written many ways, e.g. "J.R.R. Tolkien" and "Tolkien"
+	libraryCreatorSimilarity = 0.6
+)
+
+// libraryItem is something the user already has, or that a suggestion refers to
+type libraryItem struct {
+	title   string
+	creator string // Empty when it isn't known
+}
+
+func (i libraryItem) String() string {
+	if i.creator == "" {
+		return fmt.Sprintf("'%s'", i.title)
+	}
+	return fmt.Sprintf("'%s' by %s", i.title, i.creator)
+}
+
+// findInLibrary returns the item in the library the given one most likely refers to. Titles are compared
+// without edition tags, and creators only when both sides have one.
+func findInLibrary(library []libraryItem, item libraryItem) (libraryItem, bool) {
+	if strings.TrimSpace(item.title) == "" {
+		return libraryItem{}, false
+	}
+
+	for _, owned := range library {
+		if !sameTitle(owned.title, item.title) {
+			continue
+		}
+		if owned.creator != "" && item.creator != "" && !sameCreator(owned.creator, item.creator) {
+			continue
+		}
+		return owned, true
+	}
+
+	return libraryItem{}, false
+}
+
+// sameTitle reports whether two titles name the same thing. Edition tags in brackets are ignored, but any
+// difference in numbers, e.g. "Toy Story 2" and "Toy Story 3" or "Episode IV" and "Episode V", or in the
+// subtitle after a colon, e.g. "Dune: Part One" and "Dune: Part Two", makes them different.
+func sameTitle(a, b string) bool {
+	a, b = stripEditions(a), stripEditions(b)
+	if !slices.Equal(titleNumbers(a), titleNumbers(b)) {
+		return false
+	}
+
+	mainA, subA := splitSubtitle(a)
+	mainB, subB := splitSubtitle(b)
+	if normalizeString(subA) != normalizeString(subB) {
+		return false
+	}
+
+	baseA, baseB := baseTitle(mainA), baseTitle(mainB)
+	return baseA != "" && baseB != "" && calculateSimilarity(baseA, baseB) >= libraryTitleSimilarity
+}
+
+func sameCreator(a, b string) bool {
+	if calculateSimilarity(a, b) >= libraryCreatorSimilarity {
+		return true
+	}
+	// One name is often a shortened form of the other
+	a, b = normalizeString(a), normalizeString(b)
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+// editionTag matches a bracketed edition, e.g. "(Remastered)" or "[Director's Cut]"
+var editionTag = regexp.MustCompile(`\s*(\([^)]*\)|\[[^\]]*\])`)
+
+// stripEditions removes bracketed edition tags from a title
+func stripEditions(title string) string {
+	return strings.TrimSpace(editionTag.ReplaceAllString(title, ""))
+}
+
+// splitSubtitle splits a title at its first colon, e.g. "Dune: Part One" into "Dune" and "Part One"
+func splitSubtitle(title string) (string, string) {
+	main, sub, _ := strings.Cut(title, ":")
+	return strings.TrimSpace(main), strings.TrimSpace(sub)
+}
+
+// baseTitle normalizes a title without its leading article, writing any numerals as digits
+func baseTitle(title string) string {
+	return strings.TrimPrefix(strings.Join(titleWords(title), " "), "the ")
+}
+
+// romanNumeral matches the numerals that turn up in titles, I to XXXIX
+var romanNumeral = regexp.MustCompile(`^x{0,3}(ix|iv|v?i{0,3})$`)
+
+// titleWords returns the normalized words of a title with Roman numerals written as digits. A lone "I" at
+// the start of a title is a word, as in "I, Robot", rather than a numeral.
+func titleWords(title string) []string {
+	words := strings.Fields(normalizeString(title))
+	for i, word := range words {
+		if word == "i" && i == 0 {
+			continue
+		}
+		if romanNumeral.MatchString(word) {
+			words[i] = strconv.Itoa(romanValue(word))
+		}
+	}
+	return words
+}
+
+// titleNumbers returns the numbers in a title in order, reading Roman numerals as well as digits
+func titleNumbers(title string) []int {
+	var numbers []int
+	for _, word := range titleWords(title) {
+		if n, err := strconv.Atoi(word); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// romanValue converts a Roman numeral in lower case to an integer
+func romanValue(numeral string) int {
+	values := map[byte]int{'i': 1, 'v': 5, 'x': 10}
+	total := 0
+	for i := 0; i < len(numeral); i++ {
+		v := values[numeral[i]]
+		if i+1 < len(numeral) && values[numeral[i+1]] > v {
+			total -= v
+		} else {
+			total += v
+		}
+	}
+	return total
+}
+
+// suggestionSubject returns what a suggestion refers to, falling back to the top level title and artist
+// when the LLM left the content empty
+func suggestionSubject[T session.Media](s *llm.SuggestionResponse[T]) libraryItem {
+	item := libraryItem{title: s.Title, creator: s.Artist}
+
+	switch content := any(s.Content).(type) {
+	case session.Music:
+		// Recorded album and artist suggestions leave the narrower fields empty
+		item.title = firstNonEmpty(content.Title, content.Album, content.Artist, item.title)
+		item.creator = firstNonEmpty(content.Artist, item.creator)
+		if item.title == item.creator {
+			item.creator = ""
+		}
+	case session.Movie:
+		item.title = firstNonEmpty(content.Title, item.title)
+		item.creator = firstNonEmpty(content.Director, item.creator)
+	case session.TVShow:
+		item.title = firstNonEmpty(content.Title, item.title)
+		item.creator = firstNonEmpty(content.Director, item.creator)
+	case session.Book:
+		item.title = firstNonEmpty(content.Title, item.title)
+		item.creator = firstNonEmpty(content.Author, item.creator)
+	case session.VideoGame:
+		item.title = firstNonEmpty(content.Title, item.title)
+		item.creator = firstNonEmpty(content.Developer, item.creator)
+	}
+
+	return item
+}
+
+// suggestionHistory returns everything already suggested in a session, whatever the outcome
+func suggestionHistory[T session.Media](sess *session.Session[T]) []libraryItem {
+	items := make([]libraryItem, 0, len(sess.Suggestions))
+	for _, s := range sess.Suggestions {
+		items = append(items, suggestionSubject(&llm.SuggestionResponse[T]{Content: s.Content}))
+	}
+	return items
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package bindings
+
+import "testing"
+
+func TestSameTitle(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		// The same thing written differently
+		{"The Matrix", "Matrix", true},
+		{"Blade Runner", "Blade Runner (Final Cut)", true},
+		{"Apocalypse Now [Director's Cut]", "Apocalypse Now", true},
+		{"The Last of Us Part II (Remastered)", "The Last of Us Part II", true},
+		{"Rocky II", "Rocky 2", true},
+		{"Spider-Man: Into the Spider-Verse", "Spiderman: Into The Spider-Verse", true},
+		{"I, Robot", "I Robot", true},
+		{"Lord of the Rings", "Lord of the Ring", true},
+
+		// Sequels and other entries in a series
+		{"Toy Story 2", "Toy Story 3", false},
+		{"Toy Story", "Toy Story 2", false},
+		{"Blade Runner", "Blade Runner 2049", false},
+		{"Dune: Part One", "Dune: Part Two", false},
+		{"Mission: Impossible", "Mission: Impossible - Fallout", false},
+		{"Star Wars: Episode IV - A New Hope", "Star Wars: Episode V - The Empire Strikes Back", false},
+		{"Rocky II", "Rocky III", false},
+		{"The Godfather Part II", "The Godfather", false},
+
+		// Different things entirely
+		{"Heat", "Heathers", false},
+		{"Alien", "Aliens: Colonial Marines", false},
+	}
+
+	for _, tt := range tests {
+		if got := sameTitle(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameTitle(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := sameTitle(tt.b, tt.a); got != tt.want {
+			t.Errorf("sameTitle(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestFindInLibrary(t *testing.T) {
+	library := []libraryItem{
+		{title: "Toy Story", creator: "John Lasseter"},
+		{title: "Dune: Part One", creator: "Denis Villeneuve"},
+		{title: "The Hobbit", creator: "J.R.R. Tolkien"},
+	}
+
+	tests := []struct {
+		item libraryItem
+		want bool
+	}{
+		{libraryItem{title: "Toy Story", creator: "John Lasseter"}, true},
+		{libraryItem{title: "Toy Story 2", creator: "John Lasseter"}, false},
+		{libraryItem{title: "Dune: Part Two", creator: "Denis Villeneuve"}, false},
+		{libraryItem{title: "The Hobbit", creator: "Tolkien"}, true},
+		{libraryItem{title: "The Hobbit"}, true},
+		{libraryItem{title: "The Hobbit", creator: "Peter Jackson"}, false},
+		{libraryItem{title: " "}, false},
+	}
+
+	for _, tt := range tests {
+		if _, got := findInLibrary(library, tt.item); got != tt.want {
+			t.Errorf("findInLibrary(%s) found = %v, want %v", tt.item, got, tt.want)
+		}
+	}
+}
@@ -4,14 +4,10 @@ import (
 	"context"
 	"fmt"
 	"interestnaut/internal/directives"
-	"interestnaut/internal/gemini"
 	"interestnaut/internal/llm"
-	"interestnaut/internal/openai"
 	"interestnaut/internal/session"
 	"interestnaut/internal/tmdb"
 	"log"
-	"strings"
-	"sync"
 )
 
 // MovieWithSavedStatus represents a movie with its saved status
@@ -30,45 +26,22 @@ type MovieWithSavedStatus struct {
 
 type Movies struct {
 	tmdbClient             *tmdb.Client
-	llmClients             map[string]llm.Client[session.Movie]
+	llmClients             *llmClients[session.Movie]
+	recommender            *Recommender[session.Movie, *MovieWithSavedStatus, map[string]interface{}]
 	manager                session.Manager[session.Movie]
 	centralManager         session.CentralManager
 	baselineFunc, taskFunc func() string
-	mu                     sync.Mutex
+	pipeline               *pipeline[session.Movie, map[string]interface{}]
 }
 
 func NewMovieBinder(ctx context.Context, cm session.CentralManager) (*Movies, error) {
 	tmdb := tmdb.NewClient()
 
-	// Create a map of LLM clients for both providers
-	llmClients := make(map[string]llm.Client[session.Movie])
-
-	// Initialize OpenAI client
-	openaiClient, err := openai.NewClient[session.Movie](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-	} else {
-		llmClients["openai"] = openaiClient
-	}
-
-	// Initialize Gemini client
-	geminiClient, err := gemini.NewClient[session.Movie](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create Gemini client: %v", err)
-	} else {
-		llmClients["gemini"] = geminiClient
-	}
-
-	// No longer fail if no clients were created - they can be refreshed later
-	if len(llmClients) == 0 {
-		log.Printf("WARNING: No LLM clients available, credentials may need to be added")
-	}
-
 	manager := cm.Movie()
 
 	m := &Movies{
 		tmdbClient:     tmdb,
-		llmClients:     llmClients,
+		llmClients:     newLLMClients[session.Movie](cm),
 		manager:        manager,
 		centralManager: cm,
 	}
@@ -83,6 +56,46 @@ func NewMovieBinder(ctx context.Context, cm session.CentralManager) (*Movies, er
 		return directives.GetMovieBaseline(ctx, favorites)
 	}
 
+	m.recommender = &Recommender[session.Movie, *MovieWithSavedStatus, map[string]interface{}]{
+		kind:      "movie",
+		media:     session.MovieMedia,
+		cm:        cm,
+		manager:   manager,
+		clients:   m.llmClients,
+		resolve:   m.resolveSuggestedMovie,
+		mapResult: mapSuggestedMovie,
+		fallback: func() map[string]interface{} {
+			// A fallback movie object with a warning message
+			return map[string]interface{}{
+				"movie": &MovieWithSavedStatus{
+					Title:    "LLM Suggestion Unavailable",
+					Overview: "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
+					Genres:   []string{"Not Available"},
+				},
+				"reason": "No LLM clients are available. Please check your API keys in settings.",
+			}
+		},
+		library: m.libraryItems,
+		details: func(s *llm.SuggestionResponse[session.Movie], movie *MovieWithSavedStatus) (matchDetails, matchDetails) {
+			return suggestedMovieDetails(s), matchDetails{title: movie.Title, year: yearOf(movie.ReleaseDate)}
+		},
+		popularity: func(movie *MovieWithSavedStatus) (string, float64) {
+			return movie.Title, float64(movie.VoteCount)
+		},
+		scale: tmdbMovieVotes,
+	}
+
+	m.pipeline = newPipeline(&pipeline[session.Movie, map[string]interface{}]{
+		media:   session.MovieMedia,
+		cm:      cm,
+		manager: m.manager,
+		clients: m.llmClients,
+		session: func(ctx context.Context) (*session.Session[session.Movie], string) {
+			return m.manager.GetOrCreateSession(ctx, m.manager.Key(), m.taskFunc, m.baselineFunc), "movie"
+		},
+		prepare: m.prepare,
+	})
+
 	return m, nil
 }
 
@@ -252,135 +265,59 @@ func (m *Movies) GetMovieDetails(movieID int) (*MovieWithSavedStatus, error) {
 }
 
 // GetMovieSuggestion gets a movie suggestion from the LLM
-func (m *Movies) GetMovieSuggestion() (map[string]interface{}, error) {
-	ctx := context.Background()
-
-	if !m.tmdbClient.HasValidCredentials() {
-		return nil, fmt.Errorf("TMDB credentials not available")
-	}
-
-	// Get or create a session
-	sess := m.manager.GetOrCreateSession(ctx, m.manager.Key(), m.taskFunc, m.baselineFunc)
-
-	// Get current LLM provider from settings
-	provider := m.centralManager.Settings().GetLLMProvider()
-
-	// Get the appropriate client
-	llmClient, ok := m.llmClients[provider]
-	if !ok {
-		// Fall back to openai if the requested provider is not available
-		log.Printf("WARNING: Requested LLM provider '%s' not available, falling back to openai", provider)
-		llmClient, ok = m.llmClients["openai"]
-		if !ok {
-			log.Printf("WARNING: No LLM clients available, providing a default suggestion")
-			// Create a fallback movie object with a warning message
-			movie := &MovieWithSavedStatus{
-				ID:          0,
-				Title:       "LLM Suggestion Unavailable",
-				Overview:    "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
-				PosterPath:  "",
-				ReleaseDate: "",
-				VoteAverage: 0,
-				VoteCount:   0,
-				Genres:      []string{"Not Available"},
-			}
-
-			result := map[string]interface{}{
-				"movie":  movie,
-				"reason": "No LLM clients are available. Please check your API keys in settings.",
-			}
-			return result, nil
-		}
-	}
-
-	// Compose messages from the session content
-	messages, err := llmClient.ComposeMessages(ctx, &sess.Content)
-	if err != nil {
-		log.Printf("ERROR: Failed to compose messages for movie suggestion: %v", err)
-		return nil, fmt.Errorf("failed to compose messages for movie suggestion: %w", err)
-	}
-
-	// Request a suggestion from the LLM
-	suggestion, err := llmClient.SendMessages(ctx, messages...)
-	if err != nil {
-		log.Printf("ERROR: Failed to get movie suggestion: %v", err)
-		return nil, fmt.Errorf("failed to get movie suggestion: %w", err)
-	}
+func (m *Movies) GetMovieSuggestion(requestContext session.RequestContext) (map[string]interface{}, error) {
+	return m.pipeline.next(requestContext)
+}
 
-	if suggestion == nil {
-		return nil, fmt.Errorf("no suggestion available")
-	}
+// RefineSuggestion asks for a follow-up to the latest suggestion, steered by the user's instruction,
+// e.g. "like this, but darker"
+func (m *Movies) RefineSuggestion(instruction string) (map[string]interface{}, error) {
+	return m.pipeline.refine(instruction)
+}
 
-	// Try to find more details about the suggested movie from TMDB
-	query := suggestion.Title
-	resp, err := m.tmdbClient.SearchMovies(ctx, query)
-	if err != nil {
-		log.Printf("WARNING: Failed to search for suggested movie '%s': %v", query, err)
-	}
+// GetCrossMediaSuggestion suggests based on the user's taste in other kinds of media, e.g. a book
+// based on their movies and games
+func (m *Movies) GetCrossMediaSuggestion(
+	sources []session.MediaType,
+	requestContext session.RequestContext,
+) (map[string]interface{}, error) {
+	return m.pipeline.crossMedia(sources, requestContext)
+}
 
-	var movie *MovieWithSavedStatus
-	if err == nil && len(resp.Results) > 0 {
-		// Use the first result that matches closely enough
-		for _, result := range resp.Results {
-			if strings.EqualFold(result.Title, suggestion.Title) {
-				genreNames := make([]string, len(result.Genres))
-				for j, g := range result.Genres {
-					genreNames[j] = g.Name
-				}
-
-				movie = &MovieWithSavedStatus{
-					ID:          result.ID,
-					Title:       result.Title,
-					Overview:    result.Overview,
-					PosterPath:  result.PosterPath,
-					ReleaseDate: result.ReleaseDate,
-					VoteAverage: result.VoteAverage,
-					VoteCount:   result.VoteCount,
-					Genres:      genreNames,
-				}
-				break
-			}
-		}
+// GetTasteProfile returns the LLM's summary of the user's movie taste, generating or refreshing it first
+func (m *Movies) GetTasteProfile() (*session.TasteProfile, error) {
+	return m.pipeline.profile()
+}
 
-		// If no exact match, use the first result
-		if movie == nil && len(resp.Results) > 0 {
-			result := resp.Results[0]
-			genreNames := make([]string, len(result.Genres))
-			for j, g := range result.Genres {
-				genreNames[j] = g.Name
-			}
+// UpdateTasteProfile replaces the movie taste profile with the user's own version, which is kept when it's
+// next refreshed
+func (m *Movies) UpdateTasteProfile(text string) (*session.TasteProfile, error) {
+	return m.pipeline.updateProfile(text)
+}
 
-			movie = &MovieWithSavedStatus{
-				ID:          result.ID,
-				Title:       result.Title,
-				Overview:    result.Overview,
-				PosterPath:  result.PosterPath,
-				ReleaseDate: result.ReleaseDate,
-				VoteAverage: result.VoteAverage,
-				VoteCount:   result.VoteCount,
-				Genres:      genreNames,
-			}
-		}
+// prepare gets and resolves a movie suggestion in the session without recording it
+func (m *Movies) prepare(
+	ctx context.Context,
+	sess *session.Session[session.Movie],
+	_ string,
+	requestContext session.RequestContext,
+	notes ...string,
+) (*pendingSuggestion[map[string]interface{}], error) {
+	if !m.tmdbClient.HasValidCredentials() {
+		return nil, fmt.Errorf("TMDB credentials not available")
 	}
 
-	// If we didn't find anything in TMDB, create a basic movie object with the suggestion data
-	if movie == nil {
-		movie = &MovieWithSavedStatus{
-			ID:          0, // No TMDB ID
-			Title:       suggestion.Title,
-			Overview:    fmt.Sprintf("Directed by %s, written by %s. %s", suggestion.Content.Director, suggestion.Content.Writer, suggestion.PrimaryGenre),
-			PosterPath:  "", // No poster
-			ReleaseDate: "",
-			VoteAverage: 0,
-			VoteCount:   0,
-			Genres:      []string{suggestion.PrimaryGenre},
-		}
-	}
+	return m.recommender.recommend(ctx, sess, requestContext, notes...)
+}
 
-	sessionSuggestion := session.Suggestion[session.Movie]{
-		PrimaryGenre: suggestion.PrimaryGenre,
-		UserOutcome:  session.Pending,
-		Reasoning:    suggestion.Reason,
+// mapSuggestedMovie records the movie as TMDB knows it, keeping the LLM's director and writer as creators
+func mapSuggestedMovie(
+	suggestion *llm.SuggestionResponse[session.Movie],
+	movie *MovieWithSavedStatus,
+) (session.Suggestion[session.Movie], map[string]interface{}) {
+	recorded := session.Suggestion[session.Movie]{
+		Year:     yearOf(movie.ReleaseDate),
+		Creators: nonEmpty(suggestion.Content.Director, suggestion.Content.Writer),
 		Content: session.Movie{
 			Title:      movie.Title,
 			Director:   movie.Director,
@@ -389,22 +326,70 @@ func (m *Movies) GetMovieSuggestion() (map[string]interface{}, error) {
 		},
 	}
 
-	if sErr := m.manager.AddSuggestion(ctx, sess, sessionSuggestion); sErr != nil {
-		log.Printf("ERROR: Failed to add suggestion: %v", sErr)
-		return nil, fmt.Errorf("failed to add suggestion: %w", sErr)
-	}
-
 	// Return a map that can be easily serialized to JSON
-	result := map[string]interface{}{
+	return recorded, map[string]interface{}{
 		"movie":  movie,
 		"reason": suggestion.Reason,
 	}
+}
 
-	return result, nil
+// resolveSuggestedMovie looks a suggested movie up on TMDB, taking the result closest to its title and year
+func (m *Movies) resolveSuggestedMovie(
+	ctx context.Context,
+	suggestion *llm.SuggestionResponse[session.Movie],
+) (*MovieWithSavedStatus, error) {
+	query := suggestion.Title
+	resp, err := m.tmdbClient.SearchMovies(ctx, query)
+	if err != nil {
+		log.Printf("ERROR: Failed to search for suggested movie '%s': %v", query, err)
+		return nil, fmt.Errorf("failed to search for suggested movie '%s': %w", query, err)
+	}
+	if len(resp.Results) == 0 {
+		return nil, missed("'%s' could not be found on TMDB. Suggest a different movie.", suggestion.Title)
+	}
+
+	result := bestMatch(suggestedMovieDetails(suggestion), resp.Results, func(result tmdb.Movie) matchDetails {
+		return matchDetails{title: result.Title, year: yearOf(result.ReleaseDate)}
+	})
+
+	genreNames := make([]string, len(result.Genres))
+	for j, g := range result.Genres {
+		genreNames[j] = g.Name
+	}
+
+	return &MovieWithSavedStatus{
+		ID:          result.ID,
+		Title:       result.Title,
+		Overview:    result.Overview,
+		PosterPath:  result.PosterPath,
+		ReleaseDate: result.ReleaseDate,
+		VoteAverage: result.VoteAverage,
+		VoteCount:   result.VoteCount,
+		Genres:      genreNames,
+	}, nil
+}
+
+// suggestedMovieDetails is what a movie suggestion is verified on. TMDB's search doesn't return credits,
+// so the director and writer aren't compared.
+func suggestedMovieDetails(suggestion *llm.SuggestionResponse[session.Movie]) matchDetails {
+	return matchDetails{title: suggestion.Title, year: yearOf(string(suggestion.Year))}
+}
+
+// libraryItems returns the movies the user already has: favorites, the watchlist and past suggestions
+func (m *Movies) libraryItems(sess *session.Session[session.Movie]) []libraryItem {
+	items := suggestionHistory(sess)
+	for _, movie := range append(m.centralManager.Favorites().GetMovies(), m.centralManager.Queue().GetMovies()...) {
+		items = append(items, libraryItem{title: movie.Title, creator: movie.Director})
+	}
+	return items
 }
 
 // ProvideSuggestionFeedback provides feedback on a suggestion
 func (m *Movies) ProvideSuggestionFeedback(outcome session.Outcome, movieID int) error {
+	// Suggestions fetched ahead were asked for without this feedback
+	resume := m.pipeline.prefetch.invalidate()
+	defer resume()
+
 	// Get the current session
 	sess := m.manager.GetOrCreateSession(context.Background(), m.manager.Key(), m.taskFunc, m.baselineFunc)
 
@@ -471,37 +456,15 @@ func (m *Movies) ProvideSuggestionFeedback(outcome session.Outcome, movieID int)
 	return nil
 }
 
+// invalidatePrefetched implements Prefetching
+func (m *Movies) invalidatePrefetched() func() {
+	return m.pipeline.prefetch.invalidate()
+}
+
 // RefreshLLMClients attempts to recreate LLM clients that may have failed to initialize
 func (m *Movies) RefreshLLMClients() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	log.Println("Refreshing Movies LLM clients")
-
-	// Check if OpenAI client is missing
-	if _, ok := m.llmClients["openai"]; !ok {
-		openaiClient, err := openai.NewClient[session.Movie](m.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-		} else {
-			m.llmClients["openai"] = openaiClient
-			log.Println("Successfully created OpenAI client for Movies")
-		}
-	}
+	resume := m.pipeline.prefetch.invalidate()
+	defer resume()
 
-	// Check if Gemini client is missing
-	if _, ok := m.llmClients["gemini"]; !ok {
-		geminiClient, err := gemini.NewClient[session.Movie](m.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create Gemini client: %v", err)
-		} else {
-			m.llmClients["gemini"] = geminiClient
-			log.Println("Successfully created Gemini client for Movies")
-		}
-	}
-
-	// Log warning if still no clients instead of returning error
-	if len(m.llmClients) == 0 {
-		log.Printf("WARNING: Could not create any LLM clients after refresh, functionality may be limited")
-	}
+	m.llmClients.refresh("Movies")
 }
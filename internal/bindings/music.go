@@ -4,82 +4,138 @@ import (
 	"context"
 	"fmt"
 	"interestnaut/internal/directives"
-	"interestnaut/internal/gemini"
 	"interestnaut/internal/llm"
-	"interestnaut/internal/openai"
 	"interestnaut/internal/session"
 	"interestnaut/internal/spotify"
 	"log"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 const limit = 5
 
+// SpotifyAuthErrorEvent is emitted with a *spotify.AuthError when authentication fails outside a binding call
+const SpotifyAuthErrorEvent = "spotify-auth-error"
+
+const (
+	discoveryPlaylistDescription = "Music discovered with Interestnaut"
+	playlistPageSize             = 100
+)
+
 // Music represents the music-related functionality
 type Music struct {
-	spotifyAuthConfig      *spotify.AuthConfig
-	spotifyClient          spotify.Client
-	llmClients             map[string]llm.Client[session.Music]
-	manager                session.Manager[session.Music]
-	centralManager         session.CentralManager
-	baselineFunc, taskFunc func() string
-	mu                     sync.Mutex
+	spotifyAuthConfig *spotify.AuthConfig
+	spotifyClient     spotify.Client
+	library           *spotify.Library
+	llmClients        *llmClients[session.Music]
+	tracks            *Recommender[session.Music, *spotify.SimpleTrack, *spotify.SuggestedTrackInfo]
+	albums            *Recommender[session.Music, *spotify.SimpleAlbum, *spotify.SuggestedTrackInfo]
+	artists           *Recommender[session.Music, *spotify.SimpleArtist, *spotify.SuggestedTrackInfo]
+	manager           session.Manager[session.Music]
+	centralManager    session.CentralManager
+	baselineFunc      func() string
+	pipeline          *pipeline[session.Music, *spotify.SuggestedTrackInfo]
+	mu                sync.Mutex
+	playlistMu        sync.Mutex
 }
 
 func NewMusicBinder(ctx context.Context, cm session.CentralManager, clientID string) *Music {
 	sac := &spotify.AuthConfig{
 		ClientID:    clientID,
-		RedirectURI: "http://localhost:8080/callback",
-	}
-
-	// Create a map of LLM clients for both providers
-	llmClients := make(map[string]llm.Client[session.Music])
-
-	// Initialize OpenAI client
-	openaiClient, err := openai.NewClient[session.Music](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-	} else {
-		llmClients["openai"] = openaiClient
+		RedirectURI: "http://127.0.0.1:8080/callback",
 	}
 
-	// Initialize Gemini client
-	geminiClient, err := gemini.NewClient[session.Music](cm)
+	dataDir, err := session.DataDir()
 	if err != nil {
-		log.Printf("WARNING: Failed to create Gemini client: %v", err)
-	} else {
-		llmClients["gemini"] = geminiClient
+		log.Printf("WARNING: Failed to get data directory for the Spotify library snapshot: %v", err)
 	}
 
-	// No longer fail if no clients were created - they can be refreshed later
-	if len(llmClients) == 0 {
-		log.Printf("WARNING: No LLM clients available, credentials may need to be added")
+	m := &Music{
+		spotifyAuthConfig: sac,
+		spotifyClient:     spotify.NewClient(),
+		library:           spotify.NewLibrary(spotifyLibraryFile(dataDir)),
+		llmClients:        newLLMClients[session.Music](cm),
+		manager:           cm.Music(),
+		centralManager:    cm,
 	}
 
-	spotifyClient := spotify.NewClient()
-	baselineFunc := func() string {
-		return directives.GetMusicBaseline(ctx, spotifyClient)
+	// Tracks and artists are checked against their Spotify popularity; albums don't have one worth using
+	m.tracks = newMusicRecommender(m, session.TrackUnit, m.resolveSuggestedTrack, mapSuggestedTrack)
+	m.tracks.popularity = func(track *spotify.SimpleTrack) (string, float64) {
+		return track.Name, float64(track.Popularity)
 	}
-	taskFunc := func() string {
-		return directives.MusicDirective
+	m.tracks.scale = spotifyPopularity
+	m.albums = newMusicRecommender(m, session.AlbumUnit, m.resolveSuggestedAlbum, mapSuggestedAlbum)
+	m.artists = newMusicRecommender(m, session.ArtistUnit, m.resolveSuggestedArtist, mapSuggestedArtist)
+	m.artists.popularity = func(artist *spotify.SimpleArtist) (string, float64) {
+		return artist.Name, float64(artist.Popularity)
 	}
-	return &Music{
-		spotifyAuthConfig: sac,
-		spotifyClient:     spotifyClient,
-		llmClients:        llmClients,
-		manager:           cm.Music(),
-		centralManager:    cm,
-		baselineFunc:      baselineFunc,
-		taskFunc:          taskFunc,
+	m.artists.scale = spotifyPopularity
+
+	m.baselineFunc = func() string {
+		// Falls back to the snapshot on disk if Spotify can't be reached
+		if _, err := m.library.Sync(ctx, m.spotifyClient); err != nil {
+			log.Printf("WARNING: Failed to sync Spotify library: %v", err)
+		}
+		return directives.GetMusicBaseline(ctx, m.spotifyClient, m.library.Tracks())
 	}
+	m.pipeline = newPipeline(&pipeline[session.Music, *spotify.SuggestedTrackInfo]{
+		media:   session.MusicMedia,
+		cm:      cm,
+		manager: m.manager,
+		clients: m.llmClients,
+		session: func(ctx context.Context) (*session.Session[session.Music], string) {
+			sess, unit := m.getSession(ctx)
+			return sess, string(unit)
+		},
+		prepare: m.prepare,
+	})
+	return m
+}
+
+// spotifyLibraryFile is where the snapshot of the user's saved tracks is kept
+func spotifyLibraryFile(dataDir string) string {
+	return filepath.Join(dataDir, session.DefaultUserID+spotify.LibrarySuffix)
+}
+
+// LibraryStatus describes the local snapshot of the user's saved tracks
+type LibraryStatus struct {
+	TrackCount int   `json:"trackCount"`
+	SyncedAt   int64 `json:"syncedAt"`
 }
 
-// GetSavedTracks retrieves the user's saved tracks.
+// GetSavedTracks retrieves a page of the user's saved tracks from the local snapshot,
+// picking up any newly saved tracks when the first page is requested.
 func (m *Music) GetSavedTracks(limit, offset int) (*spotify.SavedTracks, error) {
-	return m.spotifyClient.GetSavedTracks(context.Background(), limit, offset)
+	if offset == 0 {
+		if _, err := m.library.Sync(context.Background(), m.spotifyClient); err != nil {
+			log.Printf("WARNING: Failed to sync Spotify library, serving the cached snapshot: %v", err)
+		}
+	}
+
+	tracks := m.library.Tracks()
+	page := &spotify.SavedTracks{
+		Items:  []spotify.SavedTrackItem{},
+		Total:  len(tracks),
+		Limit:  limit,
+		Offset: offset,
+	}
+	if offset < 0 || limit <= 0 || offset >= len(tracks) {
+		return page, nil
+	}
+
+	end := offset + limit
+	if end > len(tracks) {
+		end = len(tracks)
+	}
+	page.Items = tracks[offset:end]
+
+	return page, nil
 }
 
 // SaveTrack saves a track to the user's library.
@@ -89,7 +145,44 @@ func (m *Music) SaveTrack(trackID string) error {
 
 // RemoveTrack removes a track from the user's library.
 func (m *Music) RemoveTrack(trackID string) error {
-	return m.spotifyClient.RemoveTrack(context.Background(), trackID)
+	if err := m.spotifyClient.RemoveTrack(context.Background(), trackID); err != nil {
+		return err
+	}
+
+	if err := m.library.Remove(trackID); err != nil {
+		log.Printf("WARNING: Failed to remove track %s from the library snapshot: %v", trackID, err)
+	}
+	return nil
+}
+
+// GetLibraryStatus returns how many tracks are in the local library snapshot and when it was last synced.
+func (m *Music) GetLibraryStatus() LibraryStatus {
+	status := LibraryStatus{TrackCount: len(m.library.Tracks())}
+	if syncedAt := m.library.SyncedAt(); !syncedAt.IsZero() {
+		status.SyncedAt = syncedAt.Unix()
+	}
+	return status
+}
+
+// SyncLibrary fetches tracks saved since the last sync, returning how many were added.
+func (m *Music) SyncLibrary() (int, error) {
+	return m.library.Sync(context.Background(), m.spotifyClient)
+}
+
+// ResyncLibrary discards the local snapshot and fetches the whole library again, returning its size.
+func (m *Music) ResyncLibrary() (int, error) {
+	return m.library.Resync(context.Background(), m.spotifyClient)
+}
+
+// SearchLibrary searches the user's saved tracks locally, without a round trip to Spotify.
+func (m *Music) SearchLibrary(query string, limit int) []*spotify.SimpleTrack {
+	items := m.library.Search(query, limit)
+
+	tracks := make([]*spotify.SimpleTrack, 0, len(items))
+	for _, item := range items {
+		tracks = append(tracks, spotify.ToSimpleTrack(item.Track))
+	}
+	return tracks
 }
 
 // GetCurrentUser retrieves the current user's profile.
@@ -97,110 +190,275 @@ func (m *Music) GetCurrentUser() (*spotify.UserProfile, error) {
 	return m.spotifyClient.GetCurrentUser(context.Background())
 }
 
-// RequestNewSuggestion gets a new suggestion based on the chat history.
-func (m *Music) RequestNewSuggestion() (*spotify.SuggestedTrackInfo, error) {
-	ctx := context.Background()
+// sessionKey returns the session suggestions at the given unit are kept in. Tracks keep the
+// original music session, while albums and artists each get their own history.
+func (m *Music) sessionKey(unit session.MusicUnit) session.Key {
+	if unit == session.TrackUnit {
+		return m.manager.Key()
+	}
+	return session.Key(fmt.Sprintf("%s_%s", m.manager.Key(), unit))
+}
+
+// getSession returns the session for the unit chosen in settings
+func (m *Music) getSession(ctx context.Context) (*session.Session[session.Music], session.MusicUnit) {
+	return m.unitSession(ctx, m.centralManager.Settings().GetMusicUnit())
+}
 
-	sess := m.manager.GetOrCreateSession(ctx, m.manager.Key(), m.taskFunc, m.baselineFunc)
-
-	// Get current LLM provider from settings
-	provider := m.centralManager.Settings().GetLLMProvider()
-
-	// Get the appropriate client
-	llmClient, ok := m.llmClients[provider]
-	if !ok {
-		// Fall back to openai if the requested provider is not available
-		log.Printf("WARNING: Requested LLM provider '%s' not available, falling back to openai", provider)
-		llmClient, ok = m.llmClients["openai"]
-		if !ok {
-			log.Printf("WARNING: No LLM clients available, providing a default suggestion")
-			// Create a fallback track with a warning message
-			fallbackTrack := &spotify.SuggestedTrackInfo{
-				ID:          "",
-				Name:        "LLM Suggestion Unavailable",
-				Artist:      "System Message",
-				Album:       "Interestnaut",
-				PreviewURL:  "",
-				AlbumArtURL: "",
-				Reason:      "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
-				URI:         "",
+// unitSession returns the session for the given unit, or the one chosen in settings when it isn't valid
+func (m *Music) unitSession(ctx context.Context, unit session.MusicUnit) (*session.Session[session.Music], session.MusicUnit) {
+	if unit != session.TrackUnit && unit != session.AlbumUnit && unit != session.ArtistUnit {
+		unit = m.centralManager.Settings().GetMusicUnit()
+	}
+	taskFunc := func() string {
+		return directives.GetMusicDirective(unit)
+	}
+	return m.manager.GetOrCreateSession(ctx, m.sessionKey(unit), taskFunc, m.baselineFunc), unit
+}
+
+// RequestNewSuggestion gets a new suggestion based on the chat history, at the unit chosen in settings.
+func (m *Music) RequestNewSuggestion(requestContext session.RequestContext) (*spotify.SuggestedTrackInfo, error) {
+	return m.pipeline.next(requestContext)
+}
+
+// RefineSuggestion asks for a follow-up to the latest suggestion, steered by the user's instruction,
+// e.g. "like this, but darker"
+func (m *Music) RefineSuggestion(instruction string) (*spotify.SuggestedTrackInfo, error) {
+	return m.pipeline.refine(instruction)
+}
+
+// GetCrossMediaSuggestion suggests music based on the user's taste in other kinds of media, at the
+// unit chosen in settings
+func (m *Music) GetCrossMediaSuggestion(
+	sources []session.MediaType,
+	requestContext session.RequestContext,
+) (*spotify.SuggestedTrackInfo, error) {
+	return m.pipeline.crossMedia(sources, requestContext)
+}
+
+// GetTasteProfile returns the LLM's summary of the user's taste in music at the unit chosen in settings,
+// generating or refreshing it first
+func (m *Music) GetTasteProfile() (*session.TasteProfile, error) {
+	return m.pipeline.profile()
+}
+
+// UpdateTasteProfile replaces the music taste profile at the unit chosen in settings with the user's own
+// version, which is kept when it's next refreshed
+func (m *Music) UpdateTasteProfile(text string) (*session.TasteProfile, error) {
+	return m.pipeline.updateProfile(text)
+}
+
+// prepare gets and resolves a suggestion at the given unit without recording it
+func (m *Music) prepare(
+	ctx context.Context,
+	sess *session.Session[session.Music],
+	unit string,
+	requestContext session.RequestContext,
+	notes ...string,
+) (*pendingSuggestion[*spotify.SuggestedTrackInfo], error) {
+	switch session.MusicUnit(unit) {
+	case session.AlbumUnit:
+		return m.albums.recommend(ctx, sess, requestContext, notes...)
+	case session.ArtistUnit:
+		return m.artists.recommend(ctx, sess, requestContext, notes...)
+	}
+	return m.tracks.recommend(ctx, sess, requestContext, notes...)
+}
+
+// newMusicRecommender makes the recommender for a unit, which all share the music LLM clients and fallback
+func newMusicRecommender[M any](
+	m *Music,
+	unit session.MusicUnit,
+	resolve CatalogResolver[session.Music, M],
+	mapResult ResultMapper[session.Music, M, *spotify.SuggestedTrackInfo],
+) *Recommender[session.Music, M, *spotify.SuggestedTrackInfo] {
+	return &Recommender[session.Music, M, *spotify.SuggestedTrackInfo]{
+		kind:      string(unit),
+		media:     session.MusicMedia,
+		cm:        m.centralManager,
+		manager:   m.manager,
+		clients:   m.llmClients,
+		resolve:   resolve,
+		mapResult: mapResult,
+		fallback: func() *spotify.SuggestedTrackInfo {
+			// A fallback track with a warning message
+			return &spotify.SuggestedTrackInfo{
+				Name:   "LLM Suggestion Unavailable",
+				Artist: "System Message",
+				Album:  "Interestnaut",
+				Reason: "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
+			}
+		},
+		library: func(sess *session.Session[session.Music]) []libraryItem {
+			return m.libraryItems(sess, unit)
+		},
+		subject: func(s *llm.SuggestionResponse[session.Music]) libraryItem {
+			return musicSubject(unit, s)
+		},
+		validate: func(s *llm.SuggestionResponse[session.Music]) error {
+			// Everything but an artist is searched for along with who made it
+			if unit != session.ArtistUnit && musicSubject(unit, s).creator == "" {
+				log.Printf("ERROR: LLM content missing artist. Content: %+v", s)
+				return errors.New("LLM content response was missing artist")
 			}
-			return fallbackTrack, nil
+			return nil
+		},
+	}
+}
+
+// libraryItems returns what the user already has at the given unit: their saved Spotify tracks, the
+// albums or artists those come from, and past suggestions
+func (m *Music) libraryItems(sess *session.Session[session.Music], unit session.MusicUnit) []libraryItem {
+	items := suggestionHistory(sess)
+	seen := make(map[string]bool)
+	for _, saved := range m.library.Tracks() {
+		if saved.Track == nil {
+			continue
+		}
+		track := spotify.ToSimpleTrack(saved.Track)
+
+		var item libraryItem
+		switch unit {
+		case session.AlbumUnit:
+			item = libraryItem{title: track.Album, creator: track.Artist}
+		case session.ArtistUnit:
+			item = libraryItem{title: track.Artist}
+		default:
+			item = libraryItem{title: track.Name, creator: track.Artist}
+		}
+
+		if key := strings.ToLower(item.title + "|" + item.creator); !seen[key] {
+			seen[key] = true
+			items = append(items, item)
 		}
 	}
+	return items
+}
 
-	messages, err := llmClient.ComposeMessages(ctx, &sess.Content)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to compose message for LLM")
+// musicSubject returns what a music suggestion refers to at the given unit, reading the same fields
+// the suggestion is later resolved from
+func musicSubject(unit session.MusicUnit, s *llm.SuggestionResponse[session.Music]) libraryItem {
+	artist := firstNonEmpty(s.Artist, s.Content.Artist)
+	switch unit {
+	case session.AlbumUnit:
+		return libraryItem{title: firstNonEmpty(s.Album, s.Content.Album, s.Title), creator: artist}
+	case session.ArtistUnit:
+		return libraryItem{title: firstNonEmpty(artist, s.Title)}
 	}
+	return libraryItem{title: firstNonEmpty(s.Title, s.Content.Title), creator: artist}
+}
+
+// resolveSuggestedAlbum finds a suggested album on Spotify
+func (m *Music) resolveSuggestedAlbum(
+	ctx context.Context,
+	suggestion *llm.SuggestionResponse[session.Music],
+) (*spotify.SimpleAlbum, error) {
+	subject := musicSubject(session.AlbumUnit, suggestion)
+	album, artist := subject.title, subject.creator
 
-	// Request a new content
-	suggestion, err := llmClient.SendMessages(ctx, messages...)
+	searchCtx, searchCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer searchCancel()
+
+	albums, err := m.spotifyClient.SearchAlbums(searchCtx, fmt.Sprintf("album:\"%s\" artist:\"%s\"", album, artist), limit)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get suggestion from LLM")
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to search for suggested album '%s' by '%s'", album, artist))
+	}
+	if len(albums) == 0 {
+		// Field filters are strict about punctuation, so try a plain search before giving up
+		albums, err = m.spotifyClient.SearchAlbums(searchCtx, fmt.Sprintf("%s %s", album, artist), limit)
+		if err != nil || len(albums) == 0 {
+			return nil, missed("The album '%s' by '%s' could not be found on Spotify. Suggest a different album.", album, artist)
+		}
 	}
 
-	// Check both top-level and content fields for artist
-	artist := suggestion.Artist
-	if artist == "" {
-		artist = suggestion.Content.Artist
+	var matched *spotify.SimpleAlbum
+	bestScore := 0.0
+	for _, a := range albums {
+		score := (calculateSimilarity(a.Name, album) * 0.5) + (calculateSimilarity(a.Artist, artist) * 0.5)
+		if score > bestScore {
+			bestScore = score
+			matched = a
+		}
+	}
+	if bestScore < 0.75 {
+		return nil, missed("The album '%s' by '%s' could not be found on Spotify. Suggest a different album.", album, artist)
 	}
 
-	if suggestion.Title == "" || artist == "" {
-		log.Printf("ERROR: LLM content missing title or artist. Content: %+v", suggestion)
-		return nil, errors.New("LLM content response was missing title or artist")
+	return matched, nil
+}
+
+// mapSuggestedAlbum records the album as Spotify knows it, to be kept in the album session
+func mapSuggestedAlbum(
+	suggestion *llm.SuggestionResponse[session.Music],
+	matched *spotify.SimpleAlbum,
+) (session.Suggestion[session.Music], *spotify.SuggestedTrackInfo) {
+	recorded := session.Suggestion[session.Music]{
+		Year: yearOf(matched.ReleaseDate),
+		Content: session.Music{
+			Artist: matched.Artist,
+			Album:  matched.Name,
+		},
 	}
+	return recorded, &spotify.SuggestedTrackInfo{
+		ID:          matched.ID,
+		Name:        matched.Name,
+		Artist:      matched.Artist,
+		Album:       matched.Name,
+		AlbumArtURL: matched.AlbumArtUrl,
+		Reason:      suggestion.Reason,
+		URI:         matched.URI,
+		Unit:        string(session.AlbumUnit),
+	}
+}
 
-	searchQuery := fmt.Sprintf("track:\"%s\" artist:\"%s\"", suggestion.Title, artist)
-	if suggestion.Album != "" || suggestion.Content.Album != "" {
-		album := suggestion.Album
-		if album == "" {
-			album = suggestion.Content.Album
-		}
+// resolveSuggestedTrack matches a suggested track to one on Spotify, searching by album first when one was given
+func (m *Music) resolveSuggestedTrack(
+	ctx context.Context,
+	suggestion *llm.SuggestionResponse[session.Music],
+) (*spotify.SimpleTrack, error) {
+	subject := musicSubject(session.TrackUnit, suggestion)
+	title, artist := subject.title, subject.creator
+
+	searchQuery := fmt.Sprintf("track:\"%s\" artist:\"%s\"", title, artist)
+	if album := firstNonEmpty(suggestion.Album, suggestion.Content.Album); album != "" {
 		searchQuery += fmt.Sprintf(" album:\"%s\"", album)
 	}
 	tracks, err := m.searchTracks(ctx, searchQuery, limit)
 
 	if err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("failed to search for suggested track '%s' by '%s'", suggestion.Title, artist))
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to search for suggested track '%s' by '%s'", title, artist))
 	}
 
 	if len(tracks) == 0 {
 		// try without album before giving up
-		searchQuery = fmt.Sprintf("track:\"%s\" artist:\"%s\"", suggestion.Title, artist)
+		searchQuery = fmt.Sprintf("track:\"%s\" artist:\"%s\"", title, artist)
 		tracks, err = m.searchTracks(ctx, searchQuery, limit)
 		if err != nil || len(tracks) == 0 {
-			return nil, fmt.Errorf("could not find '%s' by '%s' on Spotify", suggestion.Title, artist)
+			return nil, missed("'%s' by '%s' could not be found on Spotify. Suggest a different track.", title, artist)
 		}
 	}
 
-	matchedTrack := m.match(ctx, suggestion.Title, artist, tracks)
+	matchedTrack := m.match(ctx, title, artist, tracks)
 	if matchedTrack == nil {
-		return nil, fmt.Errorf("could not find '%s' by '%s' on Spotify", suggestion.Title, artist)
+		return nil, missed("'%s' by '%s' could not be found on Spotify. Suggest a different track.", title, artist)
 	}
 
-	// Use the matched track's information for the session to ensure consistent key matching
-	// This is critical because the agent might suggest slightly off information
-	sessionSuggestion := session.Suggestion[session.Music]{
-		PrimaryGenre: suggestion.PrimaryGenre,
-		UserOutcome:  session.Pending,
-		Reasoning:    suggestion.Reason,
+	return matchedTrack, nil
+}
+
+// mapSuggestedTrack records the matched track rather than what was suggested, since the LLM might get
+// its details slightly off and feedback is keyed on them
+func mapSuggestedTrack(
+	suggestion *llm.SuggestionResponse[session.Music],
+	matchedTrack *spotify.SimpleTrack,
+) (session.Suggestion[session.Music], *spotify.SuggestedTrackInfo) {
+	recorded := session.Suggestion[session.Music]{
 		Content: session.Music{
 			Title:  matchedTrack.Name,
 			Artist: matchedTrack.Artist,
 			Album:  matchedTrack.Album,
 		},
 	}
-	if sErr := m.manager.AddSuggestion(
-		ctx,
-		sess,
-		sessionSuggestion,
-	); sErr != nil {
-		log.Printf("ERROR: Failed to add suggestion: %v", sErr)
-		return nil, errors.Wrap(sErr, "failed to add suggestion")
-	}
-
-	return &spotify.SuggestedTrackInfo{
+	return recorded, &spotify.SuggestedTrackInfo{
 		ID:          matchedTrack.ID,
 		Name:        matchedTrack.Name,
 		Artist:      matchedTrack.Artist,
@@ -209,23 +467,229 @@ func (m *Music) RequestNewSuggestion() (*spotify.SuggestedTrackInfo, error) {
 		AlbumArtURL: matchedTrack.AlbumArtUrl,
 		Reason:      suggestion.Reason,
 		URI:         matchedTrack.URI,
-	}, nil
+		Unit:        string(session.TrackUnit),
+	}
+}
+
+// resolveSuggestedArtist matches a suggested artist to one on Spotify
+func (m *Music) resolveSuggestedArtist(
+	ctx context.Context,
+	suggestion *llm.SuggestionResponse[session.Music],
+) (*spotify.SimpleArtist, error) {
+	artist := musicSubject(session.ArtistUnit, suggestion).title
+
+	searchCtx, searchCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer searchCancel()
+
+	artists, err := m.spotifyClient.SearchArtists(searchCtx, fmt.Sprintf("artist:\"%s\"", artist), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to search for suggested artist '%s'", artist))
+	}
+
+	// Results are ordered by relevance and popularity, so the first close enough name wins
+	var matched *spotify.SimpleArtist
+	for _, a := range artists {
+		if calculateSimilarity(a.Name, artist) >= 0.8 {
+			matched = a
+			break
+		}
+	}
+	if matched == nil {
+		return nil, missed("The artist '%s' could not be found on Spotify. Suggest a different artist.", artist)
+	}
+
+	return matched, nil
+}
+
+// mapSuggestedArtist records the artist as Spotify knows it, to be kept in the artist session
+func mapSuggestedArtist(
+	suggestion *llm.SuggestionResponse[session.Music],
+	matched *spotify.SimpleArtist,
+) (session.Suggestion[session.Music], *spotify.SuggestedTrackInfo) {
+	recorded := session.Suggestion[session.Music]{
+		Content: session.Music{
+			Artist: matched.Name,
+		},
+	}
+	return recorded, &spotify.SuggestedTrackInfo{
+		ID:          matched.ID,
+		Name:        matched.Name,
+		Artist:      matched.Name,
+		AlbumArtURL: matched.ImageUrl,
+		Reason:      suggestion.Reason,
+		URI:         matched.URI,
+		Unit:        string(session.ArtistUnit),
+	}
 }
 
-// ProvideSuggestionFeedback sends user feedback to OpenAI and records the outcome.
-func (m *Music) ProvideSuggestionFeedback(outcome session.Outcome, title, artist, album string) error {
+// ProvideSuggestionFeedback records the outcome of a suggestion at the unit it was suggested at, which may
+// no longer be the one chosen in settings, falling back to that when it isn't given; the title is ignored
+// for albums, and both title and album for artists.
+func (m *Music) ProvideSuggestionFeedback(outcome session.Outcome, title, artist, album string, unit session.MusicUnit) error {
+	// Suggestions fetched ahead were asked for without this feedback
+	resume := m.pipeline.prefetch.invalidate()
+	defer resume()
+
 	ctx := context.Background()
-	sess := m.manager.GetOrCreateSession(ctx, m.manager.Key(), m.taskFunc, m.baselineFunc)
+	sess, unit := m.unitSession(ctx, unit)
 
+	switch unit {
+	case session.AlbumUnit:
+		title = ""
+	case session.ArtistUnit:
+		title, album = "", ""
+	}
 	key := session.KeyerMusicInfo(title, artist, album)
 
 	if err := m.manager.UpdateSuggestionOutcome(ctx, sess, key, outcome); err != nil {
 		return errors.Wrap(err, "failed to update suggestion outcome")
 	}
 
+	// Only single tracks go on the discovery playlist
+	if unit == session.TrackUnit && (outcome == session.Liked || outcome == session.Added) {
+		// The outcome is already recorded, so a playlist failure shouldn't fail the feedback
+		if err := m.addToDiscoveryPlaylist(ctx, title, artist, album); err != nil {
+			log.Printf("WARNING: Failed to add '%s' by '%s' to discovery playlist: %v", title, artist, err)
+		}
+	}
+
+	return nil
+}
+
+// GetDiscoveryPlaylistTracks returns the tracks in the discovery playlist, oldest first
+func (m *Music) GetDiscoveryPlaylistTracks() ([]spotify.PlaylistItem, error) {
+	playlist := m.centralManager.Settings().GetDiscoveryPlaylist()
+	if playlist.PlaylistID == "" {
+		return []spotify.PlaylistItem{}, nil
+	}
+
+	return m.getAllPlaylistItems(context.Background(), playlist.PlaylistID)
+}
+
+// addToDiscoveryPlaylist appends a track to the discovery playlist, creating the playlist if needed
+// and trimming the oldest tracks when it's grown past the configured size
+func (m *Music) addToDiscoveryPlaylist(ctx context.Context, title, artist, album string) error {
+	m.playlistMu.Lock()
+	defer m.playlistMu.Unlock()
+
+	playlist := m.centralManager.Settings().GetDiscoveryPlaylist()
+	if !playlist.Enabled {
+		return nil
+	}
+
+	searchQuery := fmt.Sprintf("track:\"%s\" artist:\"%s\"", title, artist)
+	tracks, err := m.searchTracks(ctx, searchQuery, limit)
+	if err != nil {
+		return errors.Wrap(err, "failed to search for track")
+	}
+	track := m.match(ctx, title, artist, tracks)
+	if track == nil {
+		return fmt.Errorf("could not find '%s' by '%s' on Spotify", title, artist)
+	}
+
+	playlistID, err := m.ensureDiscoveryPlaylist(ctx, playlist)
+	if err != nil {
+		return err
+	}
+
+	items, err := m.getAllPlaylistItems(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.Track != nil && item.Track.URI == track.URI {
+			return nil
+		}
+	}
+
+	if err := m.spotifyClient.AddItemsToPlaylist(ctx, playlistID, []string{track.URI}); err != nil {
+		return err
+	}
+	log.Printf("Added '%s' by '%s' to discovery playlist", track.Name, track.Artist)
+
+	// Items are returned oldest first, so trimming from the front keeps the most recent discoveries
+	if excess := len(items) + 1 - playlist.MaxTracks; playlist.MaxTracks > 0 && excess > 0 {
+		uris := make([]string, 0, excess)
+		for _, item := range items[:excess] {
+			if item.Track != nil {
+				uris = append(uris, item.Track.URI)
+			}
+		}
+		if len(uris) > 0 {
+			if err := m.spotifyClient.RemoveItemsFromPlaylist(ctx, playlistID, uris); err != nil {
+				return errors.Wrap(err, "failed to trim discovery playlist")
+			}
+			log.Printf("Trimmed %d tracks from discovery playlist", len(uris))
+		}
+	}
+
 	return nil
 }
 
+// ensureDiscoveryPlaylist returns the ID of the discovery playlist, adopting an existing playlist with
+// the configured name or creating one, and remembers it in settings
+func (m *Music) ensureDiscoveryPlaylist(ctx context.Context, playlist session.DiscoveryPlaylist) (string, error) {
+	if playlist.PlaylistID != "" {
+		return playlist.PlaylistID, nil
+	}
+
+	user, err := m.spotifyClient.GetCurrentUser(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get current user")
+	}
+
+	var playlistID string
+	for offset := 0; playlistID == ""; offset += 50 {
+		page, err := m.spotifyClient.GetCurrentUserPlaylists(ctx, 50, offset)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list playlists")
+		}
+		for _, p := range page.Items {
+			if p.Name == playlist.Name && p.Owner.ID == user.ID {
+				playlistID = p.ID
+				break
+			}
+		}
+		if page.Next == "" || len(page.Items) == 0 {
+			break
+		}
+	}
+
+	if playlistID == "" {
+		created, err := m.spotifyClient.CreatePlaylist(ctx, user.ID, playlist.Name, discoveryPlaylistDescription, false)
+		if err != nil {
+			return "", err
+		}
+		playlistID = created.ID
+		log.Printf("Created discovery playlist '%s'", playlist.Name)
+	}
+
+	playlist.PlaylistID = playlistID
+	if err := m.centralManager.Settings().SetDiscoveryPlaylist(ctx, playlist); err != nil {
+		log.Printf("WARNING: Failed to save discovery playlist ID: %v", err)
+	}
+
+	return playlistID, nil
+}
+
+// getAllPlaylistItems reads every page of a playlist
+func (m *Music) getAllPlaylistItems(ctx context.Context, playlistID string) ([]spotify.PlaylistItem, error) {
+	var items []spotify.PlaylistItem
+	for offset := 0; ; offset += playlistPageSize {
+		page, err := m.spotifyClient.GetPlaylistItems(ctx, playlistID, playlistPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read playlist")
+		}
+		items = append(items, page.Items...)
+		if page.Next == "" || len(page.Items) == 0 {
+			break
+		}
+	}
+
+	return items, nil
+}
+
 // GetValidToken exposes the function to get a valid access token for the frontend SDK.
 func (m *Music) GetValidToken() (string, error) {
 	return spotify.GetValidToken(context.Background())
@@ -248,16 +712,64 @@ func (m *Music) PausePlaybackOnDevice(deviceID string) error {
 	return m.spotifyClient.PausePlaybackOnDevice(context.Background(), deviceID)
 }
 
+// GetDevices lists the Spotify Connect devices the user can play on.
+func (m *Music) GetDevices() ([]spotify.Device, error) {
+	return m.spotifyClient.GetDevices(context.Background())
+}
+
+// TransferPlayback moves playback to another device, such as a phone or speaker.
+func (m *Music) TransferPlayback(deviceID string, play bool) error {
+	return m.spotifyClient.TransferPlayback(context.Background(), deviceID, play)
+}
+
+// AddToQueue queues a track on the given device, or the active device if deviceID is empty.
+func (m *Music) AddToQueue(trackURI string, deviceID string) error {
+	return m.spotifyClient.AddToQueue(context.Background(), deviceID, trackURI)
+}
+
+// GetPlaybackState returns what's currently playing and on which device, or nil if nothing is.
+func (m *Music) GetPlaybackState() (*spotify.PlaybackState, error) {
+	return m.spotifyClient.GetPlaybackState(context.Background())
+}
+
 // ClearSpotifyCredentials clears stored Spotify tokens.
 func (m *Music) ClearSpotifyCredentials() error {
 	err := spotify.ClearSpotifyCredentials(context.Background())
 	spotifyClient := spotify.NewClient()
 
+	// The snapshot belongs to the disconnected account
+	if cErr := m.library.Clear(); cErr != nil {
+		log.Printf("WARNING: Failed to clear Spotify library snapshot: %v", cErr)
+	}
+
 	m.setSpotifyClient(spotifyClient)
 
 	return err
 }
 
+// AuthenticateSpotify runs the Spotify OAuth flow, returning why it failed or nil on success.
+func (m *Music) AuthenticateSpotify() *spotify.AuthError {
+	err := spotify.RunInitialAuthFlow(context.Background())
+	if err == nil {
+		return nil
+	}
+
+	var authErr *spotify.AuthError
+	if errors.As(err, &authErr) {
+		return authErr
+	}
+	return &spotify.AuthError{Code: spotify.AuthExchangeFailed, Message: err.Error()}
+}
+
+// ReportSpotifyAuthError lets the frontend know that an authentication attempt it didn't start failed
+func ReportSpotifyAuthError(ctx context.Context, err error) {
+	var authErr *spotify.AuthError
+	if !errors.As(err, &authErr) {
+		authErr = &spotify.AuthError{Code: spotify.AuthExchangeFailed, Message: err.Error()}
+	}
+	runtime.EventsEmit(ctx, SpotifyAuthErrorEvent, authErr)
+}
+
 // SearchTracks searches for tracks matching the query.
 func (m *Music) SearchTracks(query string, limit int) ([]*spotify.SimpleTrack, error) {
 	return m.spotifyClient.SearchTracks(context.Background(), query, limit)
@@ -312,36 +824,15 @@ func (m *Music) setSpotifyClient(client spotify.Client) {
 	m.spotifyClient = client
 }
 
+// invalidatePrefetched implements Prefetching
+func (m *Music) invalidatePrefetched() func() {
+	return m.pipeline.prefetch.invalidate()
+}
+
 // RefreshLLMClients attempts to recreate LLM clients that may have failed to initialize
 func (m *Music) RefreshLLMClients() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	log.Println("Refreshing Music LLM clients")
+	resume := m.pipeline.prefetch.invalidate()
+	defer resume()
 
-	// Check if OpenAI client is missing
-	if _, ok := m.llmClients["openai"]; !ok {
-		openaiClient, err := openai.NewClient[session.Music](m.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-		} else {
-			m.llmClients["openai"] = openaiClient
-			log.Println("Successfully created OpenAI client for Music")
-		}
-	}
-
-	// Check if Gemini client is missing
-	if _, ok := m.llmClients["gemini"]; !ok {
-		geminiClient, err := gemini.NewClient[session.Music](m.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create Gemini client: %v", err)
-		} else {
-			m.llmClients["gemini"] = geminiClient
-			log.Println("Successfully created Gemini client for Music")
-		}
-	}
-
-	if len(m.llmClients) == 0 {
-		log.Printf("WARNING: Could not create any LLM clients after refresh, functionality may be limited")
-	}
+	m.llmClients.refresh("Music")
 }
@@ -0,0 +1,41 @@
+package bindings
+
+import (
+	"fmt"
+	"interestnaut/internal/session"
+)
+
+// popularityScale says what counts as mainstream or niche for one popularity signal
+type popularityScale struct {
+	signal     string  // What's being measured, for constraints, e.g. "TMDB votes"
+	mainstream float64 // Familiar picks should be at least this popular
+	niche      float64 // Adventurous picks should be at most this popular
+}
+
+var (
+	tmdbMovieVotes    = popularityScale{signal: "TMDB votes", mainstream: 2000, niche: 1000}
+	tmdbTVVotes       = popularityScale{signal: "TMDB votes", mainstream: 500, niche: 250}
+	rawgRatings       = popularityScale{signal: "RAWG ratings", mainstream: 500, niche: 150}
+	spotifyPopularity = popularityScale{signal: "Spotify popularity", mainstream: 60, niche: 40}
+)
+
+// checkNovelty reports whether a suggestion's popularity fits the requested novelty, returning a constraint
+// explaining the mismatch when it doesn't. A popularity of 0 means it couldn't be looked up, which passes.
+func checkNovelty(novelty session.Novelty, scale popularityScale, title string, popularity float64) (string, bool) {
+	if popularity <= 0 {
+		return "", true
+	}
+
+	switch novelty {
+	case session.Familiar:
+		if popularity < scale.mainstream {
+			return fmt.Sprintf("'%s' is too obscure (%.0f %s). Suggest something better known.", title, popularity, scale.signal), false
+		}
+	case session.Adventurous:
+		if popularity > scale.niche {
+			return fmt.Sprintf("'%s' is too mainstream (%.0f %s). Suggest something lesser known.", title, popularity, scale.signal), false
+		}
+	}
+
+	return "", true
+}
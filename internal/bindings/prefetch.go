@@ -0,0 +1,323 @@
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"interestnaut/internal/directives"
+	"interestnaut/internal/llm"
+	"interestnaut/internal/session"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// prefetchSize is how many resolved suggestions are kept ready for each media type
+	prefetchSize = 2
+	// prefetchTimeout bounds a single background fetch so a hung request can't stall the buffer
+	prefetchTimeout = 2 * time.Minute
+)
+
+// pendingSuggestion is a resolved suggestion that's only added to the session once it's shown
+type pendingSuggestion[R any] struct {
+	result  R
+	subject libraryItem
+	record  func(ctx context.Context) error // Nil for placeholders that aren't real suggestions
+	// owned looks the suggestion up in the library as it is now, since it may have been added since the
+	// suggestion was fetched; nil when there's nothing to check
+	owned func() (libraryItem, bool)
+}
+
+// pending wraps a resolved suggestion and its result so it's recorded in the session when it's shown
+func pending[T session.Media, R any](
+	manager session.Manager[T],
+	sess *session.Session[T],
+	suggestion session.Suggestion[T],
+	result R,
+) *pendingSuggestion[R] {
+	return &pendingSuggestion[R]{
+		result:  result,
+		subject: suggestionSubject(&llm.SuggestionResponse[T]{Content: suggestion.Content}),
+		record: func(ctx context.Context) error {
+			if err := manager.AddSuggestion(ctx, sess, suggestion); err != nil {
+				log.Printf("ERROR: Failed to add suggestion: %v", err)
+				return fmt.Errorf("failed to add suggestion: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// commit records the suggestion and returns its result
+func (p *pendingSuggestion[R]) commit(ctx context.Context) (R, error) {
+	if p.record != nil {
+		if err := p.record(ctx); err != nil {
+			var empty R
+			return empty, err
+		}
+	}
+	return p.result, nil
+}
+
+// prefetcher keeps a small buffer of resolved suggestions ready in the background, so asking for the next
+// one doesn't wait on the LLM and catalog lookups. The buffer belongs to the state it was fetched under;
+// when that changes, e.g. a new provider or model, it's dropped rather than served.
+type prefetcher[R any] struct {
+	// state sums up everything that shapes the next suggestion, see suggestionState
+	state func() string
+	// fetch resolves a suggestion without recording it, with notes added for this request only
+	fetch func(ctx context.Context, notes ...string) (*pendingSuggestion[R], error)
+
+	// work is held while recording or changing the session, and while checking the state a fetch belongs to,
+	// so nothing's buffered for a state that's gone. Fetches themselves read a snapshot of the session and
+	// don't hold it, so they never keep a suggestion or feedback waiting on the LLM.
+	work sync.Mutex
+
+	mu          sync.Mutex
+	buffer      []*pendingSuggestion[R]
+	bufferState string
+	stop        context.CancelFunc // Cancels the fill in progress; nil when there isn't one
+	run         int                // Tells fills apart, so a stopped one doesn't clear a newer one's stop
+	active      bool               // Set once served from, so media that's never asked for isn't fetched
+}
+
+func newPrefetcher[R any](
+	state func() string,
+	fetch func(ctx context.Context, notes ...string) (*pendingSuggestion[R], error),
+) *prefetcher[R] {
+	return &prefetcher[R]{state: state, fetch: fetch}
+}
+
+// serve returns the next buffered suggestion, recording it, and falls back to live when the buffer is
+// empty or stale. Either way the buffer is topped up again afterwards.
+func (p *prefetcher[R]) serve(ctx context.Context, live func() (R, error)) (R, error) {
+	p.mu.Lock()
+	p.active = true
+	p.mu.Unlock()
+	defer p.fill()
+
+	p.work.Lock()
+	defer p.work.Unlock()
+
+	state := p.state()
+	for {
+		next := p.pop(state)
+		if next == nil {
+			break
+		}
+
+		// The library isn't part of the state, so what's been added to it since is checked here
+		if next.owned != nil {
+			if owned, found := next.owned(); found {
+				log.Printf("Dropping prefetched suggestion %s, which matches %s now in the library", next.subject, owned)
+				continue
+			}
+		}
+
+		// It may have been suggested some other way since it was fetched
+		result, err := next.commit(ctx)
+		if err != nil {
+			log.Printf("WARNING: Dropping prefetched suggestion %s: %v", next.subject, err)
+			continue
+		}
+		return result, nil
+	}
+
+	return live()
+}
+
+// exclusive runs a suggestion that can't come from the buffer, such as a refinement, with background
+// fetching held off so the session isn't changed underneath it
+func (p *prefetcher[R]) exclusive(live func() (R, error)) (R, error) {
+	p.work.Lock()
+	defer p.work.Unlock()
+
+	return live()
+}
+
+// pause runs fn with background fetching held off, leaving the buffer alone, for session changes that
+// don't make what's buffered stale
+func (p *prefetcher[R]) pause(fn func()) {
+	p.work.Lock()
+	defer p.work.Unlock()
+
+	fn()
+}
+
+// invalidate drops the buffer and stops background fetching until resume is called, so feedback and the
+// like can change the session without a fetch in progress being buffered afterwards
+func (p *prefetcher[R]) invalidate() (resume func()) {
+	p.mu.Lock()
+	if p.stop != nil {
+		p.stop()
+		p.stop = nil
+	}
+	p.buffer = nil
+	p.mu.Unlock()
+
+	p.work.Lock()
+	return func() {
+		p.work.Unlock()
+		p.fill()
+	}
+}
+
+// Prefetching is a binder that keeps suggestions ready ahead of time, which settings changes have to drop
+type Prefetching interface {
+	// invalidatePrefetched drops the buffered suggestions and holds off fetching more until resume is called
+	invalidatePrefetched() (resume func())
+}
+
+func (p *prefetcher[R]) pop(state string) *pendingSuggestion[R] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.bufferState != state {
+		p.buffer, p.bufferState = nil, state
+	}
+	if len(p.buffer) == 0 {
+		return nil
+	}
+
+	next := p.buffer[0]
+	p.buffer = p.buffer[1:]
+	return next
+}
+
+// fill tops the buffer up in the background, unless that's already happening or nothing's been served yet
+func (p *prefetcher[R]) fill() {
+	p.mu.Lock()
+	if p.stop != nil || !p.active {
+		p.mu.Unlock()
+		return
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	p.stop = stop
+	p.run++
+	run := p.run
+	p.mu.Unlock()
+
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			if p.run == run {
+				p.stop = nil
+			}
+			p.mu.Unlock()
+			stop()
+		}()
+
+		for p.fetchOne(ctx) {
+		}
+	}()
+}
+
+// fetchOne adds a suggestion to the buffer, reporting whether there's room for another. The session can
+// change while it's fetched, so the state is checked again before it's buffered.
+func (p *prefetcher[R]) fetchOne(ctx context.Context) bool {
+	p.work.Lock()
+	state := p.state()
+	p.work.Unlock()
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	p.mu.Lock()
+	if p.bufferState != state {
+		p.buffer, p.bufferState = nil, state
+	}
+	if len(p.buffer) >= prefetchSize {
+		p.mu.Unlock()
+		return false
+	}
+	queued := make([]string, len(p.buffer))
+	for i, b := range p.buffer {
+		queued[i] = b.subject.String()
+	}
+	p.mu.Unlock()
+
+	fetchCtx, cancel := context.WithTimeout(ctx, prefetchTimeout)
+	defer cancel()
+
+	var notes []string
+	if note := directives.GetQueuedSuggestionsContext(fetchCtx, queued); note != "" {
+		notes = append(notes, note)
+	}
+
+	next, err := p.fetch(fetchCtx, notes...)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("WARNING: Failed to prefetch suggestion: %v", err)
+		}
+		return false
+	}
+	// Placeholders such as "LLM unavailable" aren't worth keeping
+	if next == nil || next.record == nil {
+		return false
+	}
+
+	p.work.Lock()
+	defer p.work.Unlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ctx.Err() != nil || p.bufferState != state || p.state() != state {
+		return false
+	}
+	p.buffer = append(p.buffer, next)
+	return len(p.buffer) < prefetchSize
+}
+
+// suggestionState sums up what shapes the next suggestion in a session: the provider and its model, the
+// novelty setting, answered feedback, refinements, constraints and anything media specific given in extra
+func suggestionState[T session.Media](
+	cm session.CentralManager,
+	sess *session.Session[T],
+	mediaType session.MediaType,
+	extra ...string,
+) string {
+	settings := cm.Settings()
+	answered := make(map[session.Outcome]int)
+	for _, s := range sess.Suggestions {
+		if s.UserOutcome != session.Pending {
+			answered[s.UserOutcome]++
+		}
+	}
+
+	return fmt.Sprintf("%s|%s|%s|%s|%v|%d|%x|%v",
+		settings.GetLLMProvider(),
+		settings.GetChatGPTModel(),
+		settings.GetGeminiModel(),
+		settings.GetNovelty(mediaType),
+		answered,
+		len(sess.Refinements),
+		hashStrings(sess.UserConstraints),
+		extra,
+	)
+}
+
+// hashStrings fingerprints a list of strings, so that changing one is noticed as well as adding or removing one
+func hashStrings(values []string) uint64 {
+	h := fnv.New64a()
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// nextSuggestion serves from the buffer when no request context was given, since that's how suggestions are
+// prefetched, and otherwise asks live
+func nextSuggestion[R any](
+	ctx context.Context,
+	p *prefetcher[R],
+	requestContext session.RequestContext,
+	live func() (R, error),
+) (R, error) {
+	if requestContext.IsEmpty() {
+		return p.serve(ctx, live)
+	}
+	return p.exclusive(live)
+}
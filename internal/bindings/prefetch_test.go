@@ -0,0 +1,180 @@
+package bindings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHashStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		same bool
+	}{
+		{"same constraints", []string{"no horror", "short films"}, []string{"no horror", "short films"}, true},
+		{"none", nil, []string{}, true},
+		{"constraint replaced", []string{"no horror"}, []string{"no comedy"}, false},
+		{"constraint added", []string{"no horror"}, []string{"no horror", "short films"}, false},
+		{"split differently", []string{"ab", "c"}, []string{"a", "bc"}, false},
+		{"reordered", []string{"a", "b"}, []string{"b", "a"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hashStrings(tt.a) == hashStrings(tt.b); got != tt.same {
+				t.Errorf("hashStrings(%q) == hashStrings(%q) is %v, want %v", tt.a, tt.b, got, tt.same)
+			}
+		})
+	}
+}
+
+// testPrefetcher returns a prefetcher whose fetches wait for release, and a way to change its state
+func testPrefetcher(release <-chan struct{}, started chan<- struct{}) (*prefetcher[string], func(string)) {
+	var mu sync.Mutex
+	state := "initial"
+	fetched := 0
+
+	p := newPrefetcher(
+		func() string {
+			mu.Lock()
+			defer mu.Unlock()
+			return state
+		},
+		func(ctx context.Context, _ ...string) (*pendingSuggestion[string], error) {
+			started <- struct{}{}
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			fetched++
+			return &pendingSuggestion[string]{
+				result: fmt.Sprintf("suggestion %d", fetched),
+				record: func(context.Context) error { return nil },
+			}, nil
+		},
+	)
+	p.active = true
+
+	return p, func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		state = s
+	}
+}
+
+// waitForFill waits for the prefetcher's background fill to finish
+func waitForFill[R any](t *testing.T, p *prefetcher[R]) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		p.mu.Lock()
+		done := p.stop == nil
+		p.mu.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("prefetcher didn't finish filling")
+}
+
+func TestPrefetcherFill(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	started := make(chan struct{}, prefetchSize+1)
+	p, _ := testPrefetcher(release, started)
+
+	p.fill()
+	waitForFill(t, p)
+
+	if len(p.buffer) != prefetchSize {
+		t.Fatalf("buffered %d suggestions, want %d", len(p.buffer), prefetchSize)
+	}
+	if got := p.pop("initial"); got == nil || got.result != "suggestion 1" {
+		t.Errorf("pop() = %+v, want suggestion 1", got)
+	}
+	if got := p.pop("changed"); got != nil {
+		t.Errorf("pop() for a different state = %+v, want nil", got)
+	}
+}
+
+func TestPrefetcherFetchDoesNotHoldWork(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, prefetchSize+1)
+	p, setState := testPrefetcher(release, started)
+
+	p.fill()
+	<-started
+
+	// Changing the session while a fetch is in progress shouldn't wait for it
+	paused := make(chan struct{})
+	go p.pause(func() {
+		setState("changed")
+		close(paused)
+	})
+	select {
+	case <-paused:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pause waited on a fetch in progress")
+	}
+
+	close(release)
+	waitForFill(t, p)
+
+	if len(p.buffer) != 0 {
+		t.Errorf("buffered %d suggestions fetched for a state that's gone, want 0", len(p.buffer))
+	}
+}
+
+func TestPrefetcherServeSkipsLibrary(t *testing.T) {
+	var library []libraryItem
+	buffered := func(title string) *pendingSuggestion[string] {
+		subject := libraryItem{title: title}
+		return &pendingSuggestion[string]{
+			result:  title,
+			subject: subject,
+			record:  func(context.Context) error { return nil },
+			owned:   func() (libraryItem, bool) { return findInLibrary(library, subject) },
+		}
+	}
+
+	tests := []struct {
+		name    string
+		library []libraryItem
+		want    string
+	}{
+		{"nothing added", nil, "Heat"},
+		{"first added since", []libraryItem{{title: "Heat"}}, "Thief"},
+		{"both added since", []libraryItem{{title: "Thief"}, {title: "Heat"}}, "live"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPrefetcher(
+				func() string { return "state" },
+				func(context.Context, ...string) (*pendingSuggestion[string], error) {
+					return nil, errors.New("not fetching in this test")
+				},
+			)
+			p.buffer, p.bufferState = []*pendingSuggestion[string]{buffered("Heat"), buffered("Thief")}, "state"
+			library = tt.library
+
+			got, err := p.serve(context.Background(), func() (string, error) { return "live", nil })
+			waitForFill(t, p)
+			if err != nil {
+				t.Fatalf("serve() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("serve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
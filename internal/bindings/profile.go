@@ -0,0 +1,184 @@
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"interestnaut/internal/directives"
+	"interestnaut/internal/llm"
+	"interestnaut/internal/session"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// profileRefreshAnswers is how much new feedback makes a taste profile worth regenerating
+	profileRefreshAnswers = 10
+	// profileMaxAge is how long a taste profile is kept before any new feedback at all is worked into it
+	profileMaxAge = 7 * 24 * time.Hour
+	// maxProfileLength keeps a profile to a few paragraphs, including the user's edits, since it's sent with
+	// every suggestion
+	maxProfileLength = 3000
+)
+
+// refreshProfile generates the session's taste profile when it doesn't have one yet, or regenerates it when
+// there's been enough feedback since, and returns it. The previous profile is kept when that fails, or when
+// the profile's been edited or regenerated elsewhere in the meantime.
+func refreshProfile[T session.Media](
+	ctx context.Context,
+	client llm.Client[T],
+	manager session.Manager[T],
+	sess *session.Session[T],
+	media session.MediaType,
+) (*session.TasteProfile, error) {
+	current := manager.Snapshot(sess)
+	answered := answeredSuggestions(&current)
+	if profile := current.Profile; profile != nil {
+		age := time.Since(time.UnixMilli(profile.GeneratedAt))
+		if answered-profile.Answered < profileRefreshAnswers && (answered == profile.Answered || age < profileMaxAge) {
+			return profile, nil
+		}
+	}
+
+	content := session.Content[T]{
+		PrimeDirective: session.PrimeDirective{
+			Task:     directives.GetTasteProfileTask(ctx, mediaNames[media]),
+			Baseline: current.Baseline,
+		},
+		Suggestions: current.Suggestions,
+		Refinements: current.Refinements,
+	}
+	if current.Profile != nil {
+		content.UserConstraints = []string{
+			directives.GetPreviousTasteProfileContext(ctx, current.Profile.Text, current.Profile.Edited),
+		}
+	}
+
+	messages, err := client.ComposeMessages(ctx, &content)
+	if err != nil {
+		return current.Profile, fmt.Errorf("failed to compose messages for %s taste profile: %w", mediaNames[media], err)
+	}
+
+	text, err := client.Complete(ctx, messages...)
+	if err != nil {
+		return current.Profile, fmt.Errorf("failed to get %s taste profile: %w", mediaNames[media], err)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return current.Profile, fmt.Errorf("LLM response was missing the %s taste profile", mediaNames[media])
+	}
+	if len(text) > maxProfileLength {
+		text = strings.ToValidUTF8(text[:maxProfileLength], "")
+	}
+
+	profile := session.TasteProfile{
+		Text:        text,
+		GeneratedAt: time.Now().UnixMilli(),
+		Answered:    answered,
+	}
+	if err := manager.SetProfile(ctx, sess, current.Profile, profile); err != nil {
+		return manager.Snapshot(sess).Profile, fmt.Errorf("failed to save %s taste profile: %w", mediaNames[media], err)
+	}
+
+	log.Printf("Generated %s taste profile from %d answered suggestions", mediaNames[media], answered)
+	return &profile, nil
+}
+
+// tasteProfile returns the session's taste profile for the user to see, generating or refreshing it first
+func tasteProfile[T session.Media](
+	ctx context.Context,
+	clients *llmClients[T],
+	manager session.Manager[T],
+	sess *session.Session[T],
+	media session.MediaType,
+) (*session.TasteProfile, error) {
+	client, ok := clients.current()
+	if !ok {
+		if profile := manager.Snapshot(sess).Profile; profile != nil {
+			return profile, nil
+		}
+		return nil, fmt.Errorf("LLM services are unavailable, so a taste profile can't be generated")
+	}
+
+	profile, err := refreshProfile(ctx, client, manager, sess, media)
+	if err != nil {
+		if profile != nil {
+			log.Printf("WARNING: Failed to refresh %s taste profile, showing the previous one: %v", mediaNames[media], err)
+			return profile, nil
+		}
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// editProfile replaces the session's taste profile with the user's own version, which is kept when it's
+// next refreshed
+func editProfile[T session.Media](
+	ctx context.Context,
+	manager session.Manager[T],
+	sess *session.Session[T],
+	text string,
+) (*session.TasteProfile, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("the taste profile can't be empty")
+	}
+	if len(text) > maxProfileLength {
+		return nil, fmt.Errorf("taste profiles are limited to %d characters", maxProfileLength)
+	}
+
+	// A profile written from scratch covers everything so far, as a generated one would
+	current := manager.Snapshot(sess)
+	profile := session.TasteProfile{
+		GeneratedAt: time.Now().UnixMilli(),
+		Answered:    answeredSuggestions(&current),
+	}
+	if current.Profile != nil {
+		profile = *current.Profile
+	}
+	profile.Text = text
+	profile.Edited = true
+
+	if err := manager.SetProfile(ctx, sess, current.Profile, profile); err != nil {
+		return nil, fmt.Errorf("failed to save taste profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// profiledContent returns a copy of content with the taste profile in place of the baseline, and the
+// suggestions it covers listed by name rather than in full. Content without a profile is returned as is.
+func profiledContent[T session.Media](ctx context.Context, media session.MediaType, content session.Content[T]) session.Content[T] {
+	if content.Profile == nil {
+		return content
+	}
+
+	// Suggestions made or answered since the profile was generated aren't covered by it yet
+	var covered []string
+	recent := make(map[string]session.Suggestion[T])
+	for key, s := range content.Suggestions {
+		if s.SuggestedAt < content.Profile.GeneratedAt && s.RespondedAt < content.Profile.GeneratedAt {
+			covered = append(covered, describeSuggestion(s))
+		} else {
+			recent[key] = s
+		}
+	}
+	sort.Strings(covered)
+
+	content.Baseline = directives.GetTasteProfileBaseline(ctx, mediaNames[media], content.Profile.Text, covered)
+	content.Suggestions = recent
+	return content
+}
+
+// answeredSuggestions counts the session's suggestions the user has given an outcome for
+func answeredSuggestions[T session.Media](sess *session.Session[T]) int {
+	answered := 0
+	for _, s := range sess.Suggestions {
+		if s.UserOutcome != session.Pending {
+			answered++
+		}
+	}
+	return answered
+}
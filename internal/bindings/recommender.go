@@ -0,0 +1,336 @@
+package bindings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"interestnaut/internal/gemini"
+	"interestnaut/internal/llm"
+	"interestnaut/internal/openai"
+	"interestnaut/internal/ranking"
+	"interestnaut/internal/session"
+	"log"
+	"strings"
+	"sync"
+)
+
+// CatalogResolver looks a suggestion up in its media's catalog, e.g. TMDB for movies. A *catalogMiss means
+// it couldn't be found there, or can't be used, and the LLM is asked again; any other error is returned.
+type CatalogResolver[T session.Media, M any] func(ctx context.Context, s *llm.SuggestionResponse[T]) (M, error)
+
+// ResultMapper turns a resolved suggestion into what's recorded in the session and what's shown to the user.
+// Only the content, year and creators need to be set on the recorded suggestion; the rest is filled in.
+type ResultMapper[T session.Media, M any, R any] func(s *llm.SuggestionResponse[T], match M) (session.Suggestion[T], R)
+
+// catalogMiss is a suggestion that couldn't be used, along with the constraint to ask again with
+type catalogMiss struct {
+	constraint string
+}
+
+func (m *catalogMiss) Error() string {
+	return m.constraint
+}
+
+// missed reports a suggestion as unusable, explaining why in the constraint given to the LLM
+func missed(format string, args ...interface{}) error {
+	return &catalogMiss{constraint: fmt.Sprintf(format, args...)}
+}
+
+// Recommender runs the steps every suggestion goes through, whatever the media: pick the LLM client for the
+// provider in settings, ask with the taste profile and the request's notes, follow up on an unusable answer,
+// vet it against the library, feedback and novelty setting, resolve it in the catalog and verify the match,
+// and record it once it's shown.
+// T is the media, M what the catalog resolves a suggestion to and R what's returned to the frontend.
+type Recommender[T session.Media, M any, R any] struct {
+	kind    string // What's suggested, e.g. "movie", for prompts, logs and constraints
+	media   session.MediaType
+	cm      session.CentralManager
+	manager session.Manager[T]
+	clients *llmClients[T]
+
+	resolve   CatalogResolver[T, M]
+	mapResult ResultMapper[T, M, R]
+	// fallback is shown in place of a suggestion when no LLM client is available
+	fallback func() R
+	// library returns what the user already has, which is never suggested
+	library func(sess *session.Session[T]) []libraryItem
+
+	// Optional
+	// subject returns what a suggestion refers to, defaulting to suggestionSubject
+	subject func(s *llm.SuggestionResponse[T]) libraryItem
+	// validate rejects a suggestion that's missing something the media needs beyond a title, and may
+	// repair it in place
+	validate func(s *llm.SuggestionResponse[T]) error
+	// details returns what a suggestion and its catalog match are compared on to make sure they're the
+	// same thing; matches below minMatchConfidence are asked again. nil when the resolver already rejects
+	// anything that isn't a close match
+	details func(s *llm.SuggestionResponse[T], match M) (suggested, found matchDetails)
+	// notes returns prompt notes the media always adds, such as owned platforms
+	notes func(ctx context.Context) []string
+	// popularity returns a match's title and popularity on scale, to check against the novelty setting;
+	// nil when the catalog has no popularity signal
+	popularity func(match M) (string, float64)
+	scale      popularityScale
+}
+
+// recommend asks for a suggestion and resolves it without recording it, with notes added as constraints
+// for this request only. Anything the LLM suggests that's already in the library, or can't be found in the
+// catalog with confidence, is excluded and asked again, and it's an error on the last attempt. Suggestions that go against
+// feedback or the novelty setting are asked again too, but used anyway on the last attempt so the user
+// always gets something.
+func (r *Recommender[T, M, R]) recommend(
+	ctx context.Context,
+	sess *session.Session[T],
+	requestContext session.RequestContext,
+	notes ...string,
+) (*pendingSuggestion[R], error) {
+	client, ok := r.clients.current()
+	if !ok {
+		log.Printf("WARNING: No LLM clients available, providing a default suggestion")
+		return &pendingSuggestion[R]{result: r.fallback()}, nil
+	}
+
+	novelty := r.cm.Settings().GetNovelty(r.media)
+	notes = requestNotes(ctx, r.kind, requestContext, novelty, notes...)
+	if r.notes != nil {
+		notes = append(notes, r.notes(ctx)...)
+	}
+	if _, err := refreshProfile(ctx, client, r.manager, sess, r.media); err != nil {
+		log.Printf("WARNING: Failed to refresh the %s taste profile, using what's there: %v", r.kind, err)
+	}
+	// The session is read from a snapshot, since this may run in the background while it's being changed
+	snapshot := r.manager.Snapshot(sess)
+	content := withNotes(profiledContent(ctx, r.media, snapshot.Content), notes...)
+
+	library := r.library(&snapshot)
+	ranker := ranking.NewRanker(snapshot.Suggestions, ranking.DefaultThreshold)
+	constraints := append([]string{}, content.UserConstraints...)
+	var excluded []string
+
+	for attempt := 1; ; attempt++ {
+		last := attempt >= maxSuggestionAttempts
+
+		content.UserConstraints = constraints
+		if len(excluded) > 0 {
+			content.UserConstraints = append(append([]string{}, constraints...), fmt.Sprintf(
+				"The user already has these, so do not suggest any of them: %s.", strings.Join(excluded, "; ")))
+		}
+
+		suggestion, err := r.ask(ctx, client, &content)
+		if err != nil {
+			return nil, err
+		}
+
+		subject := r.subjectOf(suggestion)
+		if owned, found := findInLibrary(library, subject); found {
+			if last {
+				return nil, fmt.Errorf("could not find a %s that isn't already in your library after %d attempts", r.kind, attempt)
+			}
+
+			log.Printf("Suggested %s %s matches %s already in the library, asking again", r.kind, subject, owned)
+			excluded = append(excluded, subject.String())
+			continue
+		}
+
+		match, err := r.resolve(ctx, suggestion)
+		if err == nil {
+			err = r.verify(suggestion, match)
+		}
+		var miss *catalogMiss
+		if errors.As(err, &miss) {
+			if last {
+				return nil, fmt.Errorf("could not find a %s to suggest after %d attempts: %w", r.kind, attempt, err)
+			}
+
+			log.Printf("Suggested %s %s couldn't be used, asking again: %s", r.kind, subject, miss.constraint)
+			constraints = append(constraints, miss.constraint)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		recorded, result := r.mapResult(suggestion, match)
+		recorded.PrimaryGenre = suggestion.PrimaryGenre
+		recorded.UserOutcome = session.Pending
+		recorded.Context = storedContext(requestContext)
+		recorded.Reasoning = suggestion.Reason
+
+		if constraint, fits := r.vet(ranker, novelty, recorded, match); !fits && !last {
+			log.Printf("Suggested %s %s doesn't fit, asking again: %s", r.kind, subject, constraint)
+			constraints = append(constraints, constraint)
+			continue
+		}
+
+		next := pending(r.manager, sess, recorded, result)
+		next.owned = func() (libraryItem, bool) {
+			snapshot := r.manager.Snapshot(sess)
+			return findInLibrary(r.library(&snapshot), subject)
+		}
+		return next, nil
+	}
+}
+
+// ask sends the conversation to the LLM, following up once when the answer can't be parsed or fails
+// validation
+func (r *Recommender[T, M, R]) ask(
+	ctx context.Context,
+	client llm.Client[T],
+	content *session.Content[T],
+) (*llm.SuggestionResponse[T], error) {
+	messages, err := client.ComposeMessages(ctx, content)
+	if err != nil {
+		log.Printf("ERROR: Failed to compose messages for %s suggestion: %v", r.kind, err)
+		return nil, fmt.Errorf("failed to compose messages for %s suggestion: %w", r.kind, err)
+	}
+
+	suggestion, err := client.SendMessages(ctx, messages...)
+	if err == nil {
+		err = r.check(suggestion)
+	}
+	if err != nil && suggestion != nil && suggestion.RawResponse != "" {
+		log.Printf("%s suggestion was unusable, attempting error followup: %v", r.kind, err)
+		suggestion, err = client.ErrorFollowup(ctx, suggestion, messages...)
+		if err == nil {
+			err = r.check(suggestion)
+		}
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to get %s suggestion: %v", r.kind, err)
+		return nil, fmt.Errorf("failed to get %s suggestion: %w", r.kind, err)
+	}
+
+	return suggestion, nil
+}
+
+// check makes sure a suggestion names something, then runs the media's own validation
+func (r *Recommender[T, M, R]) check(s *llm.SuggestionResponse[T]) error {
+	if s == nil {
+		return errors.New("no suggestion available")
+	}
+	if r.validate != nil {
+		if err := r.validate(s); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(r.subjectOf(s).title) == "" {
+		return fmt.Errorf("LLM response was missing the %s's title", r.kind)
+	}
+	return nil
+}
+
+// verify makes sure the catalog matched what was suggested rather than something with a similar name,
+// missing when it's not confident it did so the LLM is asked for something else
+func (r *Recommender[T, M, R]) verify(s *llm.SuggestionResponse[T], match M) error {
+	if r.details == nil {
+		return nil
+	}
+
+	suggested, found := r.details(s, match)
+	confidence := matchConfidence(suggested, found)
+	if confidence < minMatchConfidence {
+		log.Printf("Suggested %s '%s' matched '%s' with confidence %.2f, rejecting it", r.kind, suggested.title, found.title, confidence)
+		return missed("'%s' could not be verified, so do not suggest it again. Suggest a different %s.", suggested.title, r.kind)
+	}
+	return nil
+}
+
+// vet checks a resolved suggestion against the user's feedback and the novelty setting, returning a
+// constraint explaining why it doesn't fit
+func (r *Recommender[T, M, R]) vet(
+	ranker *ranking.Ranker[T],
+	novelty session.Novelty,
+	recorded session.Suggestion[T],
+	match M,
+) (string, bool) {
+	title := r.subjectOf(&llm.SuggestionResponse[T]{Content: recorded.Content}).title
+
+	if score, ok := ranker.Accept(ranking.FromSuggestion(recorded)); !ok {
+		return fmt.Sprintf("'%s' doesn't fit what the user has liked so far (scored %.2f). Suggest a different %s.",
+			title, score, r.kind), false
+	}
+
+	if r.popularity != nil {
+		name, popularity := r.popularity(match)
+		return checkNovelty(novelty, r.scale, name, popularity)
+	}
+
+	return "", true
+}
+
+func (r *Recommender[T, M, R]) subjectOf(s *llm.SuggestionResponse[T]) libraryItem {
+	if r.subject != nil {
+		return r.subject(s)
+	}
+	return suggestionSubject(s)
+}
+
+// llmClients holds a client per provider; either may be missing until its credentials are added
+type llmClients[T session.Media] struct {
+	cm      session.CentralManager
+	mu      sync.RWMutex
+	clients map[string]llm.Client[T]
+}
+
+func newLLMClients[T session.Media](cm session.CentralManager) *llmClients[T] {
+	c := &llmClients[T]{
+		cm:      cm,
+		clients: make(map[string]llm.Client[T]),
+	}
+	c.create()
+
+	// No longer fail if no clients were created - they can be refreshed later
+	if len(c.clients) == 0 {
+		log.Printf("WARNING: No LLM clients available, credentials may need to be added")
+	}
+
+	return c
+}
+
+// current returns the client for the provider chosen in settings, falling back to openai
+func (c *llmClients[T]) current() (llm.Client[T], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	provider := c.cm.Settings().GetLLMProvider()
+	if client, ok := c.clients[provider]; ok {
+		return client, true
+	}
+
+	log.Printf("WARNING: Requested LLM provider '%s' not available, falling back to openai", provider)
+	client, ok := c.clients["openai"]
+	return client, ok
+}
+
+// refresh attempts to recreate clients that may have failed to initialize
+func (c *llmClients[T]) refresh(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	log.Printf("Refreshing %s LLM clients", name)
+	c.create()
+
+	// Log warning if still no clients instead of returning error
+	if len(c.clients) == 0 {
+		log.Printf("WARNING: Could not create any LLM clients after refresh, functionality may be limited")
+	}
+}
+
+// create makes whichever clients are missing; callers hold mu, apart from the constructor
+func (c *llmClients[T]) create() {
+	if _, ok := c.clients["openai"]; !ok {
+		if client, err := openai.NewClient[T](c.cm); err != nil {
+			log.Printf("WARNING: Failed to create OpenAI client: %v", err)
+		} else {
+			c.clients["openai"] = client
+		}
+	}
+
+	if _, ok := c.clients["gemini"]; !ok {
+		if client, err := gemini.NewClient[T](c.cm); err != nil {
+			log.Printf("WARNING: Failed to create Gemini client: %v", err)
+		} else {
+			c.clients["gemini"] = client
+		}
+	}
+}
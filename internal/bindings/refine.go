@@ -0,0 +1,66 @@
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"interestnaut/internal/directives"
+	"interestnaut/internal/llm"
+	"interestnaut/internal/session"
+	"log"
+	"strings"
+)
+
+// maxInstructionLength keeps a refinement to a sentence or two, since it's sent with every later suggestion
+const maxInstructionLength = 300
+
+// refinementNote returns the constraint asking for a follow-up to the session's latest suggestion,
+// along with a description of that suggestion to record the refinement against
+func refinementNote[T session.Media](
+	ctx context.Context,
+	sess *session.Session[T],
+	kind, instruction string,
+) (string, string, error) {
+	instruction = strings.TrimSpace(instruction)
+	if instruction == "" {
+		return "", "", fmt.Errorf("tell us how the suggestion should change")
+	}
+	if len(instruction) > maxInstructionLength {
+		return "", "", fmt.Errorf("refinements are limited to %d characters", maxInstructionLength)
+	}
+
+	latest, ok := sess.Latest()
+	if !ok {
+		return "", "", fmt.Errorf("there's no %s suggestion to refine yet", kind)
+	}
+
+	from := describeSuggestion(latest)
+	return directives.GetRefinementContext(ctx, kind, from, instruction), from, nil
+}
+
+// recordRefinement adds a refinement to the session's thread once a follow-up has been suggested, so
+// later suggestions learn from it. A follow-up that wasn't recorded, such as a fallback, is skipped.
+func recordRefinement[T session.Media](
+	ctx context.Context,
+	manager session.Manager[T],
+	sess *session.Session[T],
+	from, instruction string,
+) {
+	latest, ok := sess.Latest()
+	if !ok || describeSuggestion(latest) == from {
+		return
+	}
+
+	refinement := session.Refinement{
+		From:        from,
+		Instruction: strings.TrimSpace(instruction),
+		Result:      describeSuggestion(latest),
+	}
+	if err := manager.AddRefinement(ctx, sess, refinement); err != nil {
+		log.Printf("WARNING: Failed to record refinement of %s: %v", from, err)
+	}
+}
+
+// describeSuggestion names a recorded suggestion, e.g. "'Heat' by Michael Mann"
+func describeSuggestion[T session.Media](s session.Suggestion[T]) string {
+	return suggestionSubject(&llm.SuggestionResponse[T]{Content: s.Content}).String()
+}
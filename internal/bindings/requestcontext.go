@@ -0,0 +1,70 @@
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"interestnaut/internal/directives"
+	"interestnaut/internal/session"
+	"strings"
+)
+
+const (
+	// maxContextTextLength keeps free text such as the mood to a few words
+	maxContextTextLength = 100
+	// maxTimeAvailable is a week in minutes, which is as far ahead as anyone plans
+	maxTimeAvailable = 7 * 24 * 60
+)
+
+// checkRequestContext tidies the context a suggestion was asked for in and rejects values the frontend
+// shouldn't send; the zero value means no context was given
+func checkRequestContext(requestContext session.RequestContext) (session.RequestContext, error) {
+	requestContext.Mood = strings.TrimSpace(requestContext.Mood)
+	requestContext.Occasion = strings.TrimSpace(requestContext.Occasion)
+
+	if len(requestContext.Mood) > maxContextTextLength || len(requestContext.Occasion) > maxContextTextLength {
+		return requestContext, fmt.Errorf("mood and occasion are limited to %d characters", maxContextTextLength)
+	}
+	if requestContext.TimeAvailable < 0 || requestContext.TimeAvailable > maxTimeAvailable {
+		return requestContext, fmt.Errorf("invalid time available: %d minutes", requestContext.TimeAvailable)
+	}
+
+	switch requestContext.Company {
+	case "", session.Solo, session.Partner, session.Kids:
+	default:
+		return requestContext, fmt.Errorf("invalid company: %s", requestContext.Company)
+	}
+
+	switch requestContext.Energy {
+	case "", session.LowEnergy, session.MediumEnergy, session.HighEnergy:
+	default:
+		return requestContext, fmt.Errorf("invalid energy level: %s", requestContext.Energy)
+	}
+
+	return requestContext, nil
+}
+
+// requestNotes returns notes with the request's context and the novelty setting added, when they say anything
+func requestNotes(
+	ctx context.Context,
+	kind string,
+	requestContext session.RequestContext,
+	novelty session.Novelty,
+	notes ...string,
+) []string {
+	result := append([]string{}, notes...)
+	if note := directives.GetRequestContext(ctx, kind, requestContext); note != "" {
+		result = append(result, note)
+	}
+	if note := directives.GetNoveltyContext(ctx, kind, novelty); note != "" {
+		result = append(result, note)
+	}
+	return result
+}
+
+// storedContext returns the context to record with a suggestion, nil when none was given
+func storedContext(requestContext session.RequestContext) *session.RequestContext {
+	if requestContext.IsEmpty() {
+		return nil
+	}
+	return &requestContext
+}
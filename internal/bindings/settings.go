@@ -2,12 +2,16 @@ package bindings
 
 import (
 	"context"
+	"fmt"
 	"interestnaut/internal/session"
 	"log"
 )
 
 type Settings struct {
 	ContentManager session.CentralManager
+	// Prefetchers are the binders keeping suggestions ready for each media type, dropped when a setting they
+	// were made under changes
+	Prefetchers map[session.MediaType]Prefetching
 }
 
 func (s *Settings) GetContinuousPlayback() bool {
@@ -125,3 +129,119 @@ func (s *Settings) SetGeminiModel(model string) error {
 	log.Printf("SetGeminiModel called with value: %s", model)
 	return s.ContentManager.Settings().SetGeminiModel(context.Background(), model)
 }
+
+func (s *Settings) GetOwnedPlatforms() []session.GamePlatform {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("WARNING: ContentManager or Settings is nil in GetOwnedPlatforms")
+		return []session.GamePlatform{}
+	}
+	return s.ContentManager.Settings().GetOwnedPlatforms()
+}
+
+func (s *Settings) SetOwnedPlatforms(platforms []session.GamePlatform) error {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("ERROR: ContentManager or Settings is nil in SetOwnedPlatforms")
+		return nil
+	}
+
+	// Drop entries without a RAWG ID since they can't be used to filter searches
+	valid := make([]session.GamePlatform, 0, len(platforms))
+	for _, p := range platforms {
+		if p.ID > 0 {
+			valid = append(valid, p)
+		}
+	}
+
+	log.Printf("SetOwnedPlatforms called with %d platforms", len(valid))
+	return s.ContentManager.Settings().SetOwnedPlatforms(context.Background(), valid)
+}
+
+func (s *Settings) GetDiscoveryPlaylist() session.DiscoveryPlaylist {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("WARNING: ContentManager or Settings is nil in GetDiscoveryPlaylist")
+		return session.DiscoveryPlaylist{Name: session.DefaultDiscoveryPlaylistName}
+	}
+	return s.ContentManager.Settings().GetDiscoveryPlaylist()
+}
+
+func (s *Settings) SetDiscoveryPlaylist(enabled bool, name string, maxTracks int) error {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("ERROR: ContentManager or Settings is nil in SetDiscoveryPlaylist")
+		return nil
+	}
+
+	if name == "" {
+		name = session.DefaultDiscoveryPlaylistName
+	}
+	if maxTracks < 0 {
+		maxTracks = 0
+	}
+
+	playlist := s.ContentManager.Settings().GetDiscoveryPlaylist()
+	if playlist.Name != name {
+		// A new name means a different playlist, found or created on the next accepted suggestion
+		playlist.PlaylistID = ""
+	}
+	playlist.Enabled = enabled
+	playlist.Name = name
+	playlist.MaxTracks = maxTracks
+
+	log.Printf("SetDiscoveryPlaylist called with enabled=%v, name=%s, maxTracks=%d", enabled, name, maxTracks)
+	return s.ContentManager.Settings().SetDiscoveryPlaylist(context.Background(), playlist)
+}
+
+func (s *Settings) GetMusicUnit() session.MusicUnit {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("WARNING: ContentManager or Settings is nil in GetMusicUnit")
+		return session.DefaultMusicUnit
+	}
+	return s.ContentManager.Settings().GetMusicUnit()
+}
+
+func (s *Settings) SetMusicUnit(unit session.MusicUnit) error {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("ERROR: ContentManager or Settings is nil in SetMusicUnit")
+		return nil
+	}
+
+	// Default to tracks if invalid
+	if unit != session.TrackUnit && unit != session.AlbumUnit && unit != session.ArtistUnit {
+		unit = session.DefaultMusicUnit
+	}
+
+	log.Printf("SetMusicUnit called with value: %s", unit)
+	return s.ContentManager.Settings().SetMusicUnit(context.Background(), unit)
+}
+
+func (s *Settings) GetNovelty(mediaType session.MediaType) session.Novelty {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("WARNING: ContentManager or Settings is nil in GetNovelty")
+		return session.DefaultNovelty
+	}
+	return s.ContentManager.Settings().GetNovelty(mediaType)
+}
+
+func (s *Settings) SetNovelty(mediaType session.MediaType, novelty session.Novelty) error {
+	if s.ContentManager == nil || s.ContentManager.Settings() == nil {
+		log.Printf("ERROR: ContentManager or Settings is nil in SetNovelty")
+		return nil
+	}
+
+	if _, ok := mediaNames[mediaType]; !ok {
+		return fmt.Errorf("unknown media type: %s", mediaType)
+	}
+
+	// Default to balanced if invalid
+	if novelty != session.Familiar && novelty != session.Balanced && novelty != session.Adventurous {
+		novelty = session.DefaultNovelty
+	}
+
+	// Suggestions fetched ahead were made at the old novelty
+	if p, ok := s.Prefetchers[mediaType]; ok {
+		resume := p.invalidatePrefetched()
+		defer resume()
+	}
+
+	log.Printf("SetNovelty called with value: %s for %s", novelty, mediaType)
+	return s.ContentManager.Settings().SetNovelty(context.Background(), mediaType, novelty)
+}
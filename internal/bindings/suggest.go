@@ -0,0 +1,164 @@
+package bindings
+
+import (
+	"context"
+	"interestnaut/internal/session"
+)
+
+// maxSuggestionAttempts caps how many times the LLM is asked again when its suggestion is already in the
+// user's library, can't be found in its catalog or doesn't fit their feedback or novelty setting
+const maxSuggestionAttempts = 3
+
+// withNotes returns a copy of content with notes added as constraints for a single request
+func withNotes[T session.Media](content session.Content[T], notes ...string) session.Content[T] {
+	content.UserConstraints = append(append([]string{}, content.UserConstraints...), notes...)
+	return content
+}
+
+// pipeline is the part of suggesting that's the same for every media: serving suggestions from the
+// prefetch buffer, refining the latest one, suggesting from other media and the taste profile. A binder
+// only says which session suggestions are made in and how they're resolved, and exposes these steps as
+// its own methods.
+type pipeline[T session.Media, R any] struct {
+	media   session.MediaType
+	cm      session.CentralManager
+	manager session.Manager[T]
+	clients *llmClients[T]
+
+	// session returns the session suggestions are made in right now, and what's suggested in it for
+	// prompts, e.g. "movie"
+	session func(ctx context.Context) (*session.Session[T], string)
+	// prepare resolves a suggestion of that kind in the session without recording it, with notes added as
+	// constraints for this request only
+	prepare func(
+		ctx context.Context,
+		sess *session.Session[T],
+		kind string,
+		requestContext session.RequestContext,
+		notes ...string,
+	) (*pendingSuggestion[R], error)
+
+	// Optional
+	// state returns anything media specific that shapes the next suggestion, see suggestionState
+	state func() []string
+
+	prefetch *prefetcher[R] // Set by newPipeline
+}
+
+// newPipeline sets up the pipeline's prefetcher, once everything else is set
+func newPipeline[T session.Media, R any](p *pipeline[T, R]) *pipeline[T, R] {
+	p.prefetch = newPrefetcher(
+		func() string {
+			sess, kind := p.session(context.Background())
+			extra := []string{kind}
+			if p.state != nil {
+				extra = append(extra, p.state()...)
+			}
+			return suggestionState(p.cm, sess, p.media, extra...)
+		},
+		func(ctx context.Context, notes ...string) (*pendingSuggestion[R], error) {
+			sess, kind := p.session(ctx)
+			return p.prepare(ctx, sess, kind, session.RequestContext{}, notes...)
+		},
+	)
+	return p
+}
+
+// next returns the next suggestion, served from the buffer when the request has no context of its own
+func (p *pipeline[T, R]) next(requestContext session.RequestContext) (R, error) {
+	requestContext, err := checkRequestContext(requestContext)
+	if err != nil {
+		var empty R
+		return empty, err
+	}
+
+	ctx := context.Background()
+	return nextSuggestion(ctx, p.prefetch, requestContext, func() (R, error) {
+		sess, kind := p.session(ctx)
+		return p.suggest(ctx, sess, kind, requestContext)
+	})
+}
+
+// refine asks for a follow-up to the latest suggestion, steered by the user's instruction
+func (p *pipeline[T, R]) refine(instruction string) (R, error) {
+	ctx := context.Background()
+
+	return p.prefetch.exclusive(func() (R, error) {
+		var empty R
+		sess, kind := p.session(ctx)
+
+		note, from, err := refinementNote(ctx, sess, kind, instruction)
+		if err != nil {
+			return empty, err
+		}
+
+		result, err := p.suggest(ctx, sess, kind, session.RequestContext{}, note)
+		if err != nil {
+			return empty, err
+		}
+
+		recordRefinement(ctx, p.manager, sess, from, instruction)
+		return result, nil
+	})
+}
+
+// crossMedia suggests based on the user's taste in the other kinds of media given
+func (p *pipeline[T, R]) crossMedia(sources []session.MediaType, requestContext session.RequestContext) (R, error) {
+	var empty R
+	ctx := context.Background()
+
+	requestContext, err := checkRequestContext(requestContext)
+	if err != nil {
+		return empty, err
+	}
+
+	note, err := crossMediaNote(ctx, p.cm, p.media, sources)
+	if err != nil {
+		return empty, err
+	}
+
+	return p.prefetch.exclusive(func() (R, error) {
+		sess, kind := p.session(ctx)
+		return p.suggest(ctx, sess, kind, requestContext, note)
+	})
+}
+
+// profile returns the taste profile of the current session, generating or refreshing it first
+func (p *pipeline[T, R]) profile() (*session.TasteProfile, error) {
+	ctx := context.Background()
+
+	var profile *session.TasteProfile
+	var err error
+	p.prefetch.pause(func() {
+		sess, _ := p.session(ctx)
+		profile, err = tasteProfile(ctx, p.clients, p.manager, sess, p.media)
+	})
+	return profile, err
+}
+
+// updateProfile replaces the taste profile of the current session with the user's own version
+func (p *pipeline[T, R]) updateProfile(text string) (*session.TasteProfile, error) {
+	// Suggestions fetched ahead were asked for with the previous profile
+	resume := p.prefetch.invalidate()
+	defer resume()
+
+	ctx := context.Background()
+	sess, _ := p.session(ctx)
+	return editProfile(ctx, p.manager, sess, text)
+}
+
+// suggest gets a suggestion and records it, with notes added as constraints for this request only
+func (p *pipeline[T, R]) suggest(
+	ctx context.Context,
+	sess *session.Session[T],
+	kind string,
+	requestContext session.RequestContext,
+	notes ...string,
+) (R, error) {
+	next, err := p.prepare(ctx, sess, kind, requestContext, notes...)
+	if err != nil {
+		var empty R
+		return empty, err
+	}
+	return next.commit(ctx)
+}
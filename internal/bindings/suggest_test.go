@@ -0,0 +1,140 @@
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"interestnaut/internal/session"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testPipeline returns a movie pipeline whose suggestions are made up rather than asked for, along with
+// the notes each suggestion was prepared with
+func testPipeline(t *testing.T) (*pipeline[session.Movie, string], *[][]string, *sync.Mutex) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	cm, err := session.NewCentralManager(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("NewCentralManager() failed: %v", err)
+	}
+	manager := cm.Movie()
+
+	var mu sync.Mutex
+	var prepared [][]string
+	p := newPipeline(&pipeline[session.Movie, string]{
+		media:   session.MovieMedia,
+		cm:      cm,
+		manager: manager,
+		clients: &llmClients[session.Movie]{cm: cm}, // None, as if no API keys were set
+		session: func(ctx context.Context) (*session.Session[session.Movie], string) {
+			return manager.GetOrCreateSession(ctx, manager.Key(), func() string { return "" }, func() string { return "" }), "movie"
+		},
+		prepare: func(
+			_ context.Context,
+			sess *session.Session[session.Movie],
+			_ string,
+			_ session.RequestContext,
+			notes ...string,
+		) (*pendingSuggestion[string], error) {
+			mu.Lock()
+			defer mu.Unlock()
+			prepared = append(prepared, notes)
+
+			movie := session.Movie{Title: fmt.Sprintf("Movie %d", len(prepared)), Director: "Michael Mann"}
+			return pending(manager, sess, session.Suggestion[session.Movie]{Content: movie}, movie.Title), nil
+		},
+	})
+	return p, &prepared, &mu
+}
+
+func TestPipelineRefine(t *testing.T) {
+	p, prepared, mu := testPipeline(t)
+
+	if _, err := p.refine("darker"); err == nil || !strings.Contains(err.Error(), "no movie suggestion to refine") {
+		t.Errorf("refine() before any suggestion = %v, want an error", err)
+	}
+
+	first, err := p.next(session.RequestContext{})
+	if err != nil {
+		t.Fatalf("next() failed: %v", err)
+	}
+	waitForFill(t, p.prefetch)
+
+	refined, err := p.refine("  darker  ")
+	if err != nil {
+		t.Fatalf("refine() failed: %v", err)
+	}
+	waitForFill(t, p.prefetch)
+
+	mu.Lock()
+	var refinedWith []string
+	for _, notes := range *prepared {
+		if len(notes) == 1 && strings.Contains(notes[0], "darker") {
+			refinedWith = notes
+		}
+	}
+	mu.Unlock()
+	if refinedWith == nil || !strings.Contains(refinedWith[0], first) {
+		t.Errorf("refinement was asked for with %q, want a note refining %s", refinedWith, first)
+	}
+
+	sess, _ := p.session(context.Background())
+	snapshot := p.manager.Snapshot(sess)
+	if len(snapshot.Refinements) != 1 {
+		t.Fatalf("recorded %d refinements, want 1", len(snapshot.Refinements))
+	}
+	got := snapshot.Refinements[0]
+	if !strings.Contains(got.From, first) || !strings.Contains(got.Result, refined) || got.Instruction != "darker" {
+		t.Errorf("recorded refinement %+v, want %s refined into %s by \"darker\"", got, first, refined)
+	}
+}
+
+func TestPipelineCrossMedia(t *testing.T) {
+	p, _, _ := testPipeline(t)
+
+	tests := []struct {
+		name    string
+		sources []session.MediaType
+		wantErr string
+	}{
+		{"no sources", nil, "at least one"},
+		{"itself", []session.MediaType{session.MovieMedia}, "can't be based on movies itself"},
+		{"unknown", []session.MediaType{"podcasts"}, "unknown media type"},
+		{"nothing to go on", []session.MediaType{session.BookMedia}, "no favorites or rated suggestions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := p.crossMedia(tt.sources, session.RequestContext{}); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("crossMedia() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPipelineUpdateProfile(t *testing.T) {
+	p, _, _ := testPipeline(t)
+
+	if _, err := p.updateProfile(" "); err == nil {
+		t.Error("updateProfile() with an empty profile succeeded, want an error")
+	}
+
+	profile, err := p.updateProfile("Slow-burn crime dramas")
+	if err != nil {
+		t.Fatalf("updateProfile() failed: %v", err)
+	}
+	if !profile.Edited {
+		t.Error("updateProfile() didn't mark the profile as edited")
+	}
+
+	// Without an LLM client the edited profile is shown as it is
+	got, err := p.profile()
+	if err != nil {
+		t.Fatalf("profile() failed: %v", err)
+	}
+	if got.Text != "Slow-burn crime dramas" {
+		t.Errorf("profile() = %q, want the edited profile", got.Text)
+	}
+}
@@ -4,16 +4,36 @@ import (
 	"context"
 	"fmt"
 	"interestnaut/internal/directives"
-	"interestnaut/internal/gemini"
 	"interestnaut/internal/llm"
-	"interestnaut/internal/openai"
 	"interestnaut/internal/session"
 	"interestnaut/internal/tmdb"
 	"log"
+	"maps"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// EpisodeAlertsEvent is emitted with newly found []EpisodeAlert for followed shows
+	EpisodeAlertsEvent   = "tv-episode-alerts"
+	episodeCheckInterval = 6 * time.Hour
+	recentlyAiredWindow  = 7 * 24 * time.Hour
+	tmdbDateLayout       = "2006-01-02"
 )
 
+// EpisodeAlert describes an upcoming or just-aired episode of a show the user follows
+type EpisodeAlert struct {
+	ShowID     int          `json:"show_id"`
+	ShowName   string       `json:"show_name"`
+	PosterPath string       `json:"poster_path"`
+	Upcoming   bool         `json:"upcoming"`
+	NewSeason  bool         `json:"new_season"`
+	Episode    tmdb.Episode `json:"episode"`
+}
+
 // TVShowWithSavedStatus represents a TV show with its saved status
 type TVShowWithSavedStatus struct {
 	ID           int      `json:"id"`
@@ -31,47 +51,28 @@ type TVShowWithSavedStatus struct {
 
 type TVShows struct {
 	tmdbClient             *tmdb.Client
-	llmClients             map[string]llm.Client[session.TVShow]
+	llmClients             *llmClients[session.TVShow]
+	recommender            *Recommender[session.TVShow, *TVShowWithSavedStatus, map[string]interface{}]
 	manager                session.Manager[session.TVShow]
 	centralManager         session.CentralManager
 	baselineFunc, taskFunc func() string
-	mu                     sync.Mutex
+	pipeline               *pipeline[session.TVShow, map[string]interface{}]
+
+	alertsMu    sync.Mutex
+	followedIDs map[string]int // favorite titles resolved to TMDB IDs
 }
 
 func NewTVShowBinder(ctx context.Context, cm session.CentralManager) (*TVShows, error) {
 	client := tmdb.NewClient()
 
-	// Create a map of LLM clients for both providers
-	llmClients := make(map[string]llm.Client[session.TVShow])
-
-	// Initialize OpenAI client
-	openaiClient, err := openai.NewClient[session.TVShow](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-	} else {
-		llmClients["openai"] = openaiClient
-	}
-
-	// Initialize Gemini client
-	geminiClient, err := gemini.NewClient[session.TVShow](cm)
-	if err != nil {
-		log.Printf("WARNING: Failed to create Gemini client: %v", err)
-	} else {
-		llmClients["gemini"] = geminiClient
-	}
-
-	// No longer fail if no clients were created - they can be refreshed later
-	if len(llmClients) == 0 {
-		log.Printf("WARNING: No LLM clients available, credentials may need to be added")
-	}
-
 	manager := cm.TVShow()
 
 	t := &TVShows{
 		tmdbClient:     client,
-		llmClients:     llmClients,
+		llmClients:     newLLMClients[session.TVShow](cm),
 		manager:        manager,
 		centralManager: cm,
+		followedIDs:    make(map[string]int),
 	}
 
 	t.taskFunc = func() string {
@@ -84,6 +85,56 @@ func NewTVShowBinder(ctx context.Context, cm session.CentralManager) (*TVShows,
 		return directives.GetTVBaseline(ctx, favorites)
 	}
 
+	t.recommender = &Recommender[session.TVShow, *TVShowWithSavedStatus, map[string]interface{}]{
+		kind:      "TV show",
+		media:     session.TVMedia,
+		cm:        cm,
+		manager:   manager,
+		clients:   t.llmClients,
+		resolve:   t.resolveSuggestedShow,
+		mapResult: mapSuggestedShow,
+		fallback: func() map[string]interface{} {
+			// A fallback TV show object with a warning message
+			return map[string]interface{}{
+				"show": &TVShowWithSavedStatus{
+					Name:     "LLM Suggestion Unavailable",
+					Overview: "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
+					Genres:   []string{"Not Available"},
+				},
+				"reason": "No LLM clients are available. Please check your API keys in settings.",
+			}
+		},
+		library: t.libraryItems,
+		details: func(s *llm.SuggestionResponse[session.TVShow], show *TVShowWithSavedStatus) (matchDetails, matchDetails) {
+			return suggestedShowDetails(s), matchDetails{title: show.Name, year: yearOf(show.FirstAirDate)}
+		},
+		// Let the LLM know which shows are already in progress without persisting it as a user constraint
+		notes: func(ctx context.Context) []string {
+			if note := directives.GetTVProgressContext(ctx, cm.Progress().GetTVProgress()); note != "" {
+				return []string{note}
+			}
+			return nil
+		},
+		popularity: func(show *TVShowWithSavedStatus) (string, float64) {
+			return show.Name, float64(show.VoteCount)
+		},
+		scale: tmdbTVVotes,
+	}
+
+	t.pipeline = newPipeline(&pipeline[session.TVShow, map[string]interface{}]{
+		media:   session.TVMedia,
+		cm:      cm,
+		manager: t.manager,
+		clients: t.llmClients,
+		session: func(ctx context.Context) (*session.Session[session.TVShow], string) {
+			return t.manager.GetOrCreateSession(ctx, t.manager.Key(), t.taskFunc, t.baselineFunc), "TV show"
+		},
+		prepare: t.prepare,
+		state: func() []string {
+			return []string{fmt.Sprint(cm.Progress().GetTVProgress())}
+		},
+	})
+
 	return t, nil
 }
 
@@ -179,6 +230,305 @@ func (t *TVShows) GetWatchlist() ([]session.TVShow, error) {
 	return t.centralManager.Queue().GetTVShows(), nil
 }
 
+// GetSeasonDetails returns a season of a TV show, including its episodes
+func (t *TVShows) GetSeasonDetails(showID, seasonNumber int) (*tmdb.Season, error) {
+	if !t.tmdbClient.HasValidCredentials() {
+		return nil, fmt.Errorf("TMDB credentials not available")
+	}
+
+	season, err := t.tmdbClient.GetTVSeason(context.Background(), showID, seasonNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season details: %w", err)
+	}
+
+	return season, nil
+}
+
+// GetShowProgress returns the user's progress for every tracked TV show
+func (t *TVShows) GetShowProgress() ([]session.TVProgress, error) {
+	return t.centralManager.Progress().GetTVProgress(), nil
+}
+
+// SetShowProgress records the most recently watched episode and status for a show
+func (t *TVShows) SetShowProgress(show session.TVShow, showID, season, episode int, status session.WatchStatus) error {
+	if season < 0 || episode < 0 {
+		return fmt.Errorf("season and episode must not be negative")
+	}
+
+	switch status {
+	case session.Watching, session.Paused, session.Finished:
+	case "":
+		status = session.Watching
+	default:
+		return fmt.Errorf("unknown watch status '%s'", status)
+	}
+
+	progress := session.TVProgress{
+		Show:    show,
+		ShowID:  showID,
+		Season:  season,
+		Episode: episode,
+		Status:  status,
+	}
+
+	if err := t.centralManager.Progress().SetTVProgress(progress); err != nil {
+		return fmt.Errorf("failed to save progress: %w", err)
+	}
+
+	log.Printf("Updated progress for '%s' to season %d, episode %d (%s)", show.Title, season, episode, status)
+	return nil
+}
+
+// AdvanceEpisode marks the next episode of a show as watched, rolling over into the next season
+// and marking the show finished once the final episode has been watched
+func (t *TVShows) AdvanceEpisode(title string) (*session.TVProgress, error) {
+	ctx := context.Background()
+
+	progress, found := t.findProgress(title)
+	if !found {
+		return nil, fmt.Errorf("TV show '%s' is not being tracked", title)
+	}
+
+	if progress.ShowID == 0 || !t.tmdbClient.HasValidCredentials() {
+		return nil, fmt.Errorf("cannot look up episodes for '%s' without a TMDB ID and credentials", title)
+	}
+
+	details, err := t.tmdbClient.GetTVShowDetails(ctx, progress.ShowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TV show details: %w", err)
+	}
+
+	progress = advanceProgress(progress, details)
+
+	if err := t.centralManager.Progress().SetTVProgress(progress); err != nil {
+		return nil, fmt.Errorf("failed to save progress: %w", err)
+	}
+
+	return &progress, nil
+}
+
+// advanceProgress moves progress on by one episode, on to the next season once the last episode of this
+// one is reached. A season whose episode count TMDB doesn't know is never treated as finished, so the
+// rest of it isn't skipped.
+func advanceProgress(progress session.TVProgress, details *tmdb.TVShow) session.TVProgress {
+	// Episode counts per season come with the show details; season 0 holds specials and is skipped
+	episodeCount := func(seasonNumber int) int {
+		for _, season := range details.Seasons {
+			if season.SeasonNumber == seasonNumber {
+				return season.EpisodeCount
+			}
+		}
+		return 0
+	}
+
+	if progress.Season == 0 {
+		progress.Season = 1
+		progress.Episode = 0
+	}
+
+	count := episodeCount(progress.Season)
+	if count == 0 || progress.Episode < count {
+		progress.Episode++
+	} else if progress.Season < details.NumberOfSeasons {
+		progress.Season++
+		progress.Episode = 1
+	}
+
+	progress.Status = session.Watching
+	if count := episodeCount(progress.Season); progress.Season >= details.NumberOfSeasons && count > 0 && progress.Episode >= count {
+		progress.Status = session.Finished
+	}
+
+	return progress
+}
+
+// RemoveShowProgress stops tracking progress for a show
+func (t *TVShows) RemoveShowProgress(title string) error {
+	progress, found := t.findProgress(title)
+	if !found {
+		return fmt.Errorf("TV show '%s' is not being tracked", title)
+	}
+
+	if err := t.centralManager.Progress().RemoveTVProgress(progress.Show, progress.ShowID); err != nil {
+		return fmt.Errorf("failed to remove progress: %w", err)
+	}
+
+	log.Printf("Removed progress for '%s'", title)
+	return nil
+}
+
+func (t *TVShows) findProgress(title string) (session.TVProgress, bool) {
+	for _, p := range t.centralManager.Progress().GetTVProgress() {
+		if strings.EqualFold(p.Show.Title, title) {
+			return p, true
+		}
+	}
+	return session.TVProgress{}, false
+}
+
+// GetEpisodeAlerts lists upcoming and recently aired episodes for favorited and in-progress shows
+func (t *TVShows) GetEpisodeAlerts() ([]EpisodeAlert, error) {
+	if !t.tmdbClient.HasValidCredentials() {
+		return nil, fmt.Errorf("TMDB credentials not available")
+	}
+
+	return t.collectEpisodeAlerts(context.Background(), time.Now()), nil
+}
+
+// StartEpisodeAlerts periodically checks followed shows for new episodes and seasons,
+// emitting EpisodeAlertsEvent with any alerts that haven't been sent before; stops when ctx is done
+func StartEpisodeAlerts(ctx context.Context, t *TVShows) {
+	check := func() {
+		if !t.tmdbClient.HasValidCredentials() {
+			return
+		}
+
+		now := time.Now()
+		alerts := t.collectEpisodeAlerts(ctx, now)
+
+		previous := t.centralManager.Progress().GetNotifiedEpisodes()
+		notified := maps.Clone(previous)
+		fresh := freshEpisodeAlerts(alerts, notified, now)
+		if !maps.Equal(notified, previous) {
+			if err := t.centralManager.Progress().SetNotifiedEpisodes(notified); err != nil {
+				log.Printf("WARNING: Failed to save notified episodes, alerts may repeat after a restart: %v", err)
+			}
+		}
+
+		if len(fresh) > 0 {
+			log.Printf("Found %d new episode alerts", len(fresh))
+			runtime.EventsEmit(ctx, EpisodeAlertsEvent, fresh)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(episodeCheckInterval)
+		defer ticker.Stop()
+
+		check()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				check()
+			}
+		}
+	}()
+}
+
+// freshEpisodeAlerts returns the alerts that haven't been sent before, recording them in notified along with
+// their air dates. Episodes that aired longer ago than an alert is shown for are dropped from notified, since
+// they won't come up again, and so are those without a date that parses, since alerts are only made for
+// episodes with one.
+func freshEpisodeAlerts(alerts []EpisodeAlert, notified map[int]string, now time.Time) []EpisodeAlert {
+	var fresh []EpisodeAlert
+	for _, alert := range alerts {
+		if _, ok := notified[alert.Episode.ID]; !ok {
+			fresh = append(fresh, alert)
+		}
+		// Air dates can move, so the latest one is kept
+		notified[alert.Episode.ID] = alert.Episode.AirDate
+	}
+
+	for id, date := range notified {
+		if airDate, err := time.Parse(tmdbDateLayout, date); err != nil || now.Sub(airDate) > recentlyAiredWindow {
+			delete(notified, id)
+		}
+	}
+
+	return fresh
+}
+
+// collectEpisodeAlerts gathers next and last episode data for every followed show
+func (t *TVShows) collectEpisodeAlerts(ctx context.Context, now time.Time) []EpisodeAlert {
+	alerts := []EpisodeAlert{}
+
+	for _, showID := range t.followedShowIDs(ctx) {
+		details, err := t.tmdbClient.GetTVShowDetails(ctx, showID)
+		if err != nil {
+			log.Printf("WARNING: Failed to get details for followed show %d: %v", showID, err)
+			continue
+		}
+
+		if next := details.NextEpisodeToAir; next != nil {
+			if airDate, err := time.Parse(tmdbDateLayout, next.AirDate); err == nil && !airDate.Before(now.Truncate(24*time.Hour)) {
+				alerts = append(alerts, newEpisodeAlert(details, *next, true))
+			}
+		}
+
+		if last := details.LastEpisodeToAir; last != nil {
+			if airDate, err := time.Parse(tmdbDateLayout, last.AirDate); err == nil && now.Sub(airDate) <= recentlyAiredWindow {
+				alerts = append(alerts, newEpisodeAlert(details, *last, false))
+			}
+		}
+	}
+
+	return alerts
+}
+
+func newEpisodeAlert(show *tmdb.TVShow, episode tmdb.Episode, upcoming bool) EpisodeAlert {
+	return EpisodeAlert{
+		ShowID:     show.ID,
+		ShowName:   show.Name,
+		PosterPath: show.PosterPath,
+		Upcoming:   upcoming,
+		NewSeason:  episode.EpisodeNumber == 1,
+		Episode:    episode,
+	}
+}
+
+// followedShowIDs returns TMDB IDs for favorites and shows still being watched,
+// resolving favorite titles through search and caching the result
+func (t *TVShows) followedShowIDs(ctx context.Context) []int {
+	seen := make(map[int]bool)
+	var ids []int
+
+	add := func(id int) {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, p := range t.centralManager.Progress().GetTVProgress() {
+		if p.Status != session.Finished {
+			add(p.ShowID)
+		}
+	}
+
+	for _, fav := range t.centralManager.Favorites().GetTVShows() {
+		t.alertsMu.Lock()
+		id, ok := t.followedIDs[fav.Title]
+		t.alertsMu.Unlock()
+
+		if !ok {
+			resp, err := t.tmdbClient.SearchTVShows(ctx, fav.Title)
+			if err != nil {
+				log.Printf("WARNING: Failed to resolve favorite show '%s': %v", fav.Title, err)
+				continue
+			}
+			for _, result := range resp.Results {
+				if strings.EqualFold(result.Name, fav.Title) {
+					id = result.ID
+					break
+				}
+			}
+			if id == 0 && len(resp.Results) > 0 {
+				id = resp.Results[0].ID
+			}
+
+			t.alertsMu.Lock()
+			t.followedIDs[fav.Title] = id
+			t.alertsMu.Unlock()
+		}
+
+		add(id)
+	}
+
+	return ids
+}
+
 // HasValidCredentials checks if the TMDB client has valid credentials
 func (t *TVShows) HasValidCredentials() bool {
 	return t.tmdbClient.HasValidCredentials()
@@ -277,190 +627,147 @@ func (t *TVShows) GetTVShowDetails(showID int) (*TVShowWithSavedStatus, error) {
 }
 
 // GetTVShowSuggestion gets a TV show suggestion from the LLM
-func (t *TVShows) GetTVShowSuggestion() (map[string]interface{}, error) {
-	ctx := context.Background()
+func (t *TVShows) GetTVShowSuggestion(requestContext session.RequestContext) (map[string]interface{}, error) {
+	return t.pipeline.next(requestContext)
+}
 
-	if !t.tmdbClient.HasValidCredentials() {
-		return nil, fmt.Errorf("TMDB credentials not available")
-	}
+// RefineSuggestion asks for a follow-up to the latest suggestion, steered by the user's instruction,
+// e.g. "like this, but darker"
+func (t *TVShows) RefineSuggestion(instruction string) (map[string]interface{}, error) {
+	return t.pipeline.refine(instruction)
+}
 
-	// Get or create a session
-	sess := t.manager.GetOrCreateSession(ctx, t.manager.Key(), t.taskFunc, t.baselineFunc)
+// GetCrossMediaSuggestion suggests based on the user's taste in other kinds of media, e.g. a book
+// based on their movies and games
+func (t *TVShows) GetCrossMediaSuggestion(
+	sources []session.MediaType,
+	requestContext session.RequestContext,
+) (map[string]interface{}, error) {
+	return t.pipeline.crossMedia(sources, requestContext)
+}
 
-	// Get current LLM provider from settings
-	provider := t.centralManager.Settings().GetLLMProvider()
+// GetTasteProfile returns the LLM's summary of the user's TV taste, generating or refreshing it first
+func (t *TVShows) GetTasteProfile() (*session.TasteProfile, error) {
+	return t.pipeline.profile()
+}
 
-	// Get the appropriate client
-	llmClient, ok := t.llmClients[provider]
-	if !ok {
-		// Fall back to openai if the requested provider is not available
-		log.Printf("WARNING: Requested LLM provider '%s' not available, falling back to openai", provider)
-		llmClient, ok = t.llmClients["openai"]
-		if !ok {
-			log.Printf("WARNING: No LLM clients available, providing a default suggestion")
-			// Create a fallback TV show object with a warning message
-			show := &TVShowWithSavedStatus{
-				ID:           0,
-				Name:         "LLM Suggestion Unavailable",
-				Overview:     "LLM services are currently unavailable. Please ensure your API keys are correctly configured.",
-				PosterPath:   "",
-				FirstAirDate: "",
-				VoteAverage:  0,
-				VoteCount:    0,
-				Genres:       []string{"Not Available"},
-				IsSaved:      false,
-			}
+// UpdateTasteProfile replaces the TV taste profile with the user's own version, which is kept when it's
+// next refreshed
+func (t *TVShows) UpdateTasteProfile(text string) (*session.TasteProfile, error) {
+	return t.pipeline.updateProfile(text)
+}
 
-			result := map[string]interface{}{
-				"show":   show,
-				"reason": "No LLM clients are available. Please check your API keys in settings.",
-			}
-			return result, nil
-		}
+// prepare gets and resolves a TV show suggestion in the session without recording it
+func (t *TVShows) prepare(
+	ctx context.Context,
+	sess *session.Session[session.TVShow],
+	_ string,
+	requestContext session.RequestContext,
+	notes ...string,
+) (*pendingSuggestion[map[string]interface{}], error) {
+	if !t.tmdbClient.HasValidCredentials() {
+		return nil, fmt.Errorf("TMDB credentials not available")
 	}
 
-	// Compose messages from the session content
-	messages, err := llmClient.ComposeMessages(ctx, &sess.Content)
-	if err != nil {
-		log.Printf("ERROR: Failed to compose messages for TV show suggestion: %v", err)
-		return nil, fmt.Errorf("failed to compose messages for TV show suggestion: %w", err)
-	}
+	return t.recommender.recommend(ctx, sess, requestContext, notes...)
+}
 
-	// Request a suggestion from the LLM
-	suggestion, err := llmClient.SendMessages(ctx, messages...)
-	if err != nil {
-		log.Printf("ERROR: Failed to get TV show suggestion: %v", err)
-		return nil, fmt.Errorf("failed to get TV show suggestion: %w", err)
+// mapSuggestedShow records the show as TMDB knows it, keeping the LLM's director and writer as creators
+func mapSuggestedShow(
+	suggestion *llm.SuggestionResponse[session.TVShow],
+	show *TVShowWithSavedStatus,
+) (session.Suggestion[session.TVShow], map[string]interface{}) {
+	recorded := session.Suggestion[session.TVShow]{
+		Year:     yearOf(show.FirstAirDate),
+		Creators: nonEmpty(suggestion.Content.Director, suggestion.Content.Writer),
+		Content: session.TVShow{
+			Title:      show.Name, // Note: Converting from Name to Title
+			Director:   show.Director,
+			Writer:     show.Writer,
+			PosterPath: show.PosterPath,
+		},
 	}
 
-	if suggestion == nil {
-		return nil, fmt.Errorf("no suggestion available")
+	// Return a map that can be easily serialized to JSON
+	return recorded, map[string]interface{}{
+		"show":   show,
+		"reason": suggestion.Reason,
 	}
+}
 
-	// Try to find more details about the suggested TV show from TMDB
+// resolveSuggestedShow looks a suggested TV show up on TMDB, taking the result closest to its title and year
+func (t *TVShows) resolveSuggestedShow(
+	ctx context.Context,
+	suggestion *llm.SuggestionResponse[session.TVShow],
+) (*TVShowWithSavedStatus, error) {
 	query := suggestion.Title
 	resp, err := t.tmdbClient.SearchTVShows(ctx, query)
 	if err != nil {
-		log.Printf("WARNING: Failed to search for suggested TV show '%s': %v", query, err)
+		log.Printf("ERROR: Failed to search for suggested TV show '%s': %v", query, err)
+		return nil, fmt.Errorf("failed to search for suggested TV show '%s': %w", query, err)
+	}
+	if len(resp.Results) == 0 {
+		return nil, missed("'%s' could not be found on TMDB. Suggest a different show.", suggestion.Title)
 	}
 
-	var show *TVShowWithSavedStatus
-	if err == nil && len(resp.Results) > 0 {
-		// Use the first result that matches closely enough
-		for _, result := range resp.Results {
-			if strings.EqualFold(result.Name, suggestion.Title) {
-				genreNames := make([]string, len(result.Genres))
-				for j, g := range result.Genres {
-					genreNames[j] = g.Name
-				}
-
-				// Check if this show is saved in favorites
-				favorites := t.centralManager.Favorites().GetTVShows()
-				isSaved := false
-				for _, fav := range favorites {
-					if strings.EqualFold(fav.Title, result.Name) {
-						isSaved = true
-						break
-					}
-				}
-
-				show = &TVShowWithSavedStatus{
-					ID:           result.ID,
-					Name:         result.Name,
-					Overview:     result.Overview,
-					PosterPath:   result.PosterPath,
-					FirstAirDate: result.FirstAirDate,
-					VoteAverage:  result.VoteAverage,
-					VoteCount:    result.VoteCount,
-					Genres:       genreNames,
-					IsSaved:      isSaved,
-				}
-				break
-			}
-		}
-
-		// If no exact match, use the first result
-		if show == nil && len(resp.Results) > 0 {
-			result := resp.Results[0]
-			genreNames := make([]string, len(result.Genres))
-			for j, g := range result.Genres {
-				genreNames[j] = g.Name
-			}
-
-			// Check if this show is saved in favorites
-			favorites := t.centralManager.Favorites().GetTVShows()
-			isSaved := false
-			for _, fav := range favorites {
-				if strings.EqualFold(fav.Title, result.Name) {
-					isSaved = true
-					break
-				}
-			}
+	result := bestMatch(suggestedShowDetails(suggestion), resp.Results, func(result tmdb.TVShow) matchDetails {
+		return matchDetails{title: result.Name, year: yearOf(result.FirstAirDate)}
+	})
 
-			show = &TVShowWithSavedStatus{
-				ID:           result.ID,
-				Name:         result.Name,
-				Overview:     result.Overview,
-				PosterPath:   result.PosterPath,
-				FirstAirDate: result.FirstAirDate,
-				VoteAverage:  result.VoteAverage,
-				VoteCount:    result.VoteCount,
-				Genres:       genreNames,
-				IsSaved:      isSaved,
-			}
-		}
+	genreNames := make([]string, len(result.Genres))
+	for j, g := range result.Genres {
+		genreNames[j] = g.Name
 	}
 
-	// If we didn't find anything in TMDB, create a basic TV show object with the suggestion data
-	if show == nil {
-		// Check if this show is saved in favorites
-		favorites := t.centralManager.Favorites().GetTVShows()
-		isSaved := false
-		for _, fav := range favorites {
-			if strings.EqualFold(fav.Title, suggestion.Title) {
-				isSaved = true
-				break
-			}
-		}
-
-		show = &TVShowWithSavedStatus{
-			ID:         0, // No TMDB ID
-			Name:       suggestion.Title,
-			Overview:   fmt.Sprintf("Directed by %s, written by %s. %s", suggestion.Content.Director, suggestion.Content.Writer, suggestion.PrimaryGenre),
-			PosterPath: "", // No poster
-			Genres:     []string{suggestion.PrimaryGenre},
-			IsSaved:    isSaved,
+	// Check if this show is saved in favorites
+	favorites := t.centralManager.Favorites().GetTVShows()
+	isSaved := false
+	for _, fav := range favorites {
+		if strings.EqualFold(fav.Title, result.Name) {
+			isSaved = true
+			break
 		}
 	}
 
-	sessionSuggestion := session.Suggestion[session.TVShow]{
-		PrimaryGenre: suggestion.PrimaryGenre,
-		UserOutcome:  session.Pending,
-		Reasoning:    suggestion.Reason,
-		Content: session.TVShow{
-			Title:      show.Name, // Note: Converting from Name to Title
-			Director:   show.Director,
-			Writer:     show.Writer,
-			PosterPath: show.PosterPath,
-		},
-	}
+	return &TVShowWithSavedStatus{
+		ID:           result.ID,
+		Name:         result.Name,
+		Overview:     result.Overview,
+		PosterPath:   result.PosterPath,
+		FirstAirDate: result.FirstAirDate,
+		VoteAverage:  result.VoteAverage,
+		VoteCount:    result.VoteCount,
+		Genres:       genreNames,
+		IsSaved:      isSaved,
+	}, nil
+}
 
-	if sErr := t.manager.AddSuggestion(ctx, sess, sessionSuggestion); sErr != nil {
-		log.Printf("ERROR: Failed to add suggestion: %v", sErr)
-		return nil, fmt.Errorf("failed to add suggestion: %w", sErr)
-	}
+// suggestedShowDetails is what a TV show suggestion is verified on. TMDB's search doesn't return credits,
+// so the director and writer aren't compared.
+func suggestedShowDetails(suggestion *llm.SuggestionResponse[session.TVShow]) matchDetails {
+	return matchDetails{title: suggestion.Title, year: yearOf(string(suggestion.Year))}
+}
 
-	// Return a map that can be easily serialized to JSON
-	result := map[string]interface{}{
-		"show":   show,
-		"reason": suggestion.Reason,
+// libraryItems returns the shows the user already has: favorites, the watchlist, anything they're
+// watching or have watched, and past suggestions
+func (t *TVShows) libraryItems(sess *session.Session[session.TVShow]) []libraryItem {
+	items := suggestionHistory(sess)
+	shows := append(t.centralManager.Favorites().GetTVShows(), t.centralManager.Queue().GetTVShows()...)
+	for _, progress := range t.centralManager.Progress().GetTVProgress() {
+		shows = append(shows, progress.Show)
 	}
-
-	return result, nil
+	for _, show := range shows {
+		items = append(items, libraryItem{title: show.Title, creator: show.Director})
+	}
+	return items
 }
 
 // ProvideSuggestionFeedback provides feedback on a suggestion
 func (t *TVShows) ProvideSuggestionFeedback(outcome session.Outcome, showID int) error {
+	// Suggestions fetched ahead were asked for without this feedback
+	resume := t.pipeline.prefetch.invalidate()
+	defer resume()
+
 	// Get the current session
 	sess := t.manager.GetOrCreateSession(context.Background(), t.manager.Key(), t.taskFunc, t.baselineFunc)
 
@@ -527,37 +834,15 @@ func (t *TVShows) ProvideSuggestionFeedback(outcome session.Outcome, showID int)
 	return nil
 }
 
+// invalidatePrefetched implements Prefetching
+func (t *TVShows) invalidatePrefetched() func() {
+	return t.pipeline.prefetch.invalidate()
+}
+
 // RefreshLLMClients attempts to recreate LLM clients that may have failed to initialize
 func (t *TVShows) RefreshLLMClients() {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
-	log.Println("Refreshing TV LLM clients")
-
-	// Check if OpenAI client is missing
-	if _, ok := t.llmClients["openai"]; !ok {
-		openaiClient, err := openai.NewClient[session.TVShow](t.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create OpenAI client: %v", err)
-		} else {
-			t.llmClients["openai"] = openaiClient
-			log.Println("Successfully created OpenAI client for TV")
-		}
-	}
-
-	// Check if Gemini client is missing
-	if _, ok := t.llmClients["gemini"]; !ok {
-		geminiClient, err := gemini.NewClient[session.TVShow](t.centralManager)
-		if err != nil {
-			log.Printf("WARNING: Failed to create Gemini client: %v", err)
-		} else {
-			t.llmClients["gemini"] = geminiClient
-			log.Println("Successfully created Gemini client for TV")
-		}
-	}
+	resume := t.pipeline.prefetch.invalidate()
+	defer resume()
 
-	// Log warning if still no clients instead of returning error
-	if len(t.llmClients) == 0 {
-		log.Printf("WARNING: Could not create any LLM clients after refresh, functionality may be limited")
-	}
+	t.llmClients.refresh("TV")
 }
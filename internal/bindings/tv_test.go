@@ -0,0 +1,117 @@
+package bindings
+
+import (
+	"interestnaut/internal/session"
+	"interestnaut/internal/tmdb"
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestFreshEpisodeAlerts(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	alert := func(id int, airDate string) EpisodeAlert {
+		return EpisodeAlert{Episode: tmdb.Episode{ID: id, AirDate: airDate}}
+	}
+
+	tests := []struct {
+		name         string
+		alerts       []EpisodeAlert
+		notified     map[int]string
+		wantFresh    []int
+		wantNotified map[int]string
+	}{
+		{
+			name:         "first check",
+			alerts:       []EpisodeAlert{alert(1, "2026-10-20"), alert(2, "2026-10-15")},
+			notified:     map[int]string{},
+			wantFresh:    []int{1, 2},
+			wantNotified: map[int]string{1: "2026-10-20", 2: "2026-10-15"},
+		},
+		{
+			name:         "already notified before a restart",
+			alerts:       []EpisodeAlert{alert(1, "2026-10-20"), alert(3, "2026-10-25")},
+			notified:     map[int]string{1: "2026-10-20"},
+			wantFresh:    []int{3},
+			wantNotified: map[int]string{1: "2026-10-20", 3: "2026-10-25"},
+		},
+		{
+			name:         "air date moved",
+			alerts:       []EpisodeAlert{alert(1, "2026-11-03")},
+			notified:     map[int]string{1: "2026-10-20"},
+			wantNotified: map[int]string{1: "2026-11-03"},
+		},
+		{
+			name:         "aired too long ago to come up again",
+			alerts:       nil,
+			notified:     map[int]string{1: "2026-10-01", 2: "2026-10-14"},
+			wantNotified: map[int]string{2: "2026-10-14"},
+		},
+		{
+			name:         "no air date to expire by",
+			alerts:       []EpisodeAlert{alert(1, "2026-10-20")},
+			notified:     map[int]string{1: "2026-10-20", 2: "", 3: "soon"},
+			wantNotified: map[int]string{1: "2026-10-20"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fresh := freshEpisodeAlerts(tt.alerts, tt.notified, now)
+
+			var ids []int
+			for _, a := range fresh {
+				ids = append(ids, a.Episode.ID)
+			}
+			if len(ids) != len(tt.wantFresh) {
+				t.Fatalf("fresh alerts = %v, want %v", ids, tt.wantFresh)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantFresh[i] {
+					t.Errorf("fresh alerts = %v, want %v", ids, tt.wantFresh)
+				}
+			}
+			if !maps.Equal(tt.notified, tt.wantNotified) {
+				t.Errorf("notified = %v, want %v", tt.notified, tt.wantNotified)
+			}
+		})
+	}
+}
+
+func TestAdvanceProgress(t *testing.T) {
+	show := &tmdb.TVShow{
+		NumberOfSeasons: 2,
+		Seasons:         []tmdb.Season{{SeasonNumber: 0, EpisodeCount: 3}, {SeasonNumber: 1, EpisodeCount: 10}, {SeasonNumber: 2, EpisodeCount: 8}},
+	}
+	unknownCounts := &tmdb.TVShow{NumberOfSeasons: 2}
+
+	tests := []struct {
+		name         string
+		details      *tmdb.TVShow
+		season       int
+		episode      int
+		wantSeason   int
+		wantEpisode  int
+		wantFinished bool
+	}{
+		{"not started", show, 0, 0, 1, 1, false},
+		{"mid season", show, 1, 4, 1, 5, false},
+		{"last episode of a season", show, 1, 10, 2, 1, false},
+		{"final episode", show, 2, 7, 2, 8, true},
+		{"already finished", show, 2, 8, 2, 8, true},
+		{"unknown episode count", unknownCounts, 1, 10, 1, 11, false},
+		{"unknown count in the last season", unknownCounts, 2, 5, 2, 6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := advanceProgress(session.TVProgress{Season: tt.season, Episode: tt.episode}, tt.details)
+			if got.Season != tt.wantSeason || got.Episode != tt.wantEpisode {
+				t.Errorf("advanceProgress() = S%dE%d, want S%dE%d", got.Season, got.Episode, tt.wantSeason, tt.wantEpisode)
+			}
+			if finished := got.Status == session.Finished; finished != tt.wantFinished {
+				t.Errorf("advanceProgress() status = %s, want finished: %v", got.Status, tt.wantFinished)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package bindings
 
 import (
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -90,3 +91,26 @@ func calculateSimilarity(s1, s2 string) float64 {
 	}
 	return 1.0 - float64(distance)/float64(maxLen)
 }
+
+// yearOf returns the year a date such as "1999-03-31" starts with, or 0 when there isn't one
+func yearOf(date string) int {
+	if len(date) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// nonEmpty returns the values that aren't blank
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
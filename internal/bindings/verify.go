@@ -0,0 +1,82 @@
+package bindings
+
+// minMatchConfidence is how sure a catalog match has to be that it's what the LLM suggested before it's
+// shown; anything less is treated as a suggestion the catalog doesn't have, which is often one the LLM made up
+const minMatchConfidence = 0.75
+
+// Weights of each detail in a match's confidence, out of those both sides know
+const (
+	titleWeight   = 0.6
+	yearWeight    = 0.2
+	creatorWeight = 0.2
+)
+
+// matchDetails are what a suggestion and its catalog match are compared on. A year of 0 or no creators
+// means it isn't known, and it's left out of the comparison rather than counted against the match.
+type matchDetails struct {
+	title    string
+	year     int
+	creators []string
+}
+
+// matchConfidence scores how likely found is to be what was suggested, from 0 to 1. The title counts for
+// most of it, while the year and creators confirm or undermine it when both sides know them. Years that
+// don't agree rule the match out, since remakes and reboots often share a title exactly.
+func matchConfidence(suggested, found matchDetails) float64 {
+	score := calculateSimilarity(suggested.title, found.title) * titleWeight
+	weight := titleWeight
+
+	if suggested.year != 0 && found.year != 0 {
+		similarity := yearSimilarity(suggested.year, found.year)
+		if similarity == 0 {
+			return 0
+		}
+		score += similarity * yearWeight
+		weight += yearWeight
+	}
+
+	if len(suggested.creators) > 0 && len(found.creators) > 0 {
+		score += creatorSimilarity(suggested.creators, found.creators) * creatorWeight
+		weight += creatorWeight
+	}
+
+	return score / weight
+}
+
+// yearSimilarity allows a year either way, since festival, regional and early access releases often
+// disagree on when something first came out
+func yearSimilarity(a, b int) float64 {
+	switch diff := a - b; {
+	case diff == 0:
+		return 1
+	case diff == 1 || diff == -1:
+		return 0.5
+	}
+	return 0
+}
+
+// creatorSimilarity is how closely the best matching pair of creators agree, since the LLM and catalogs
+// often only list some of them
+func creatorSimilarity(suggested, found []string) float64 {
+	best := 0.0
+	for _, s := range suggested {
+		for _, f := range found {
+			if similarity := calculateSimilarity(s, f); similarity > best {
+				best = similarity
+			}
+		}
+	}
+	return best
+}
+
+// bestMatch returns the candidate most likely to be what was suggested
+func bestMatch[C any](suggested matchDetails, candidates []C, details func(c C) matchDetails) C {
+	var best C
+	bestConfidence := -1.0
+	for _, c := range candidates {
+		if confidence := matchConfidence(suggested, details(c)); confidence > bestConfidence {
+			best, bestConfidence = c, confidence
+		}
+	}
+	return best
+}
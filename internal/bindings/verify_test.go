@@ -0,0 +1,124 @@
+package bindings
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatchConfidence(t *testing.T) {
+	tests := []struct {
+		name             string
+		suggested, found matchDetails
+		want             float64
+	}{
+		{
+			name:      "title only",
+			suggested: matchDetails{title: "Heat"},
+			found:     matchDetails{title: "Heat", year: 1995},
+			want:      1,
+		},
+		{
+			name:      "title and year",
+			suggested: matchDetails{title: "Heat", year: 1995},
+			found:     matchDetails{title: "Heat", year: 1995},
+			want:      1,
+		},
+		{
+			name:      "year off by one",
+			suggested: matchDetails{title: "Heat", year: 1995},
+			found:     matchDetails{title: "Heat", year: 1996},
+			want:      (titleWeight + yearWeight/2) / (titleWeight + yearWeight),
+		},
+		{
+			name:      "remake with the same title",
+			suggested: matchDetails{title: "Dune", year: 1984},
+			found:     matchDetails{title: "Dune", year: 2021},
+			want:      0,
+		},
+		{
+			name:      "matching creator",
+			suggested: matchDetails{title: "Hades", year: 2020, creators: []string{"Supergiant Games"}},
+			found:     matchDetails{title: "Hades", year: 2020, creators: []string{"Supergiant Games"}},
+			want:      1,
+		},
+		{
+			name:      "best pair of creators",
+			suggested: matchDetails{title: "Hades", creators: []string{"Supergiant Games"}},
+			found:     matchDetails{title: "Hades", creators: []string{"Someone Else", "Supergiant Games"}},
+			want:      1,
+		},
+		{
+			name:      "different creator",
+			suggested: matchDetails{title: "Hades", creators: []string{"abc"}},
+			found:     matchDetails{title: "Hades", creators: []string{"xyz"}},
+			want:      titleWeight / (titleWeight + creatorWeight),
+		},
+		{
+			name:      "different title",
+			suggested: matchDetails{title: "abcd"},
+			found:     matchDetails{title: "wxyz"},
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchConfidence(tt.suggested, tt.found); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("matchConfidence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchConfidenceThreshold(t *testing.T) {
+	tests := []struct {
+		name             string
+		suggested, found matchDetails
+		want             bool
+	}{
+		{"exact match", matchDetails{title: "Heat", year: 1995}, matchDetails{title: "Heat", year: 1995}, true},
+		{"release a year apart", matchDetails{title: "Heat", year: 1995}, matchDetails{title: "Heat", year: 1996}, true},
+		{"wrong year", matchDetails{title: "Heat", year: 1995}, matchDetails{title: "Heat", year: 1986}, false},
+		{"different title", matchDetails{title: "Heat", year: 1995}, matchDetails{title: "Heathers", year: 1995}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchConfidence(tt.suggested, tt.found) >= minMatchConfidence; got != tt.want {
+				t.Errorf("matchConfidence() >= minMatchConfidence = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	candidates := []matchDetails{
+		{title: "Dune", year: 1984},
+		{title: "Dune", year: 2021},
+		{title: "Dune: Part Two", year: 2024},
+	}
+	details := func(c matchDetails) matchDetails { return c }
+
+	tests := []struct {
+		name      string
+		suggested matchDetails
+		want      matchDetails
+	}{
+		{"year picks the remake", matchDetails{title: "Dune", year: 2021}, candidates[1]},
+		{"year picks the original", matchDetails{title: "Dune", year: 1984}, candidates[0]},
+		{"first of equals without a year", matchDetails{title: "Dune"}, candidates[0]},
+		{"title picks the sequel", matchDetails{title: "Dune: Part Two"}, candidates[2]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bestMatch(tt.suggested, candidates, details); got.title != tt.want.title || got.year != tt.want.year {
+				t.Errorf("bestMatch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := bestMatch(matchDetails{title: "Dune"}, []*matchDetails(nil), func(c *matchDetails) matchDetails { return *c }); got != nil {
+		t.Errorf("bestMatch() with no candidates = %+v, want nil", got)
+	}
+}
@@ -19,6 +19,7 @@ IMPORTANT RULES:
 {
   "title": "Book Title",
   "author": "Author's Name",
+  "year": "The year the book was first published, e.g. 1999",
   "primary_genre": "The primary genre of the book",
   "reason": "Detailed explanation of why this book matches their taste, referencing specific patterns in their library or likes/dislikes."
 }
@@ -28,6 +29,7 @@ IMPORTANT RULES:
 6. Refer to baseline for a list of books in the user's library.
 7. One suggestion per response.
 8. In the event of no historic data, suggest a book at random.
+9. If user_constraints include a list of catalog candidates, suggest exactly one book from that list, choosing the one that best fits the user's taste.
 
 Do not include any other text in your response, only the JSON object to be parsed.
 `
@@ -48,3 +50,18 @@ func GetBookBaseline(_ context.Context, favorites []session.Book) string {
 
 	return sb.String()
 }
+
+// GetBookCandidatesContext lists books pulled from the catalog for the model to choose between,
+// along with the subjects they were found under
+func GetBookCandidatesContext(_ context.Context, subjects []string, candidates []session.Book) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("The user's favorite books are mostly about: %s. ", strings.Join(subjects, ", ")))
+	sb.WriteString("Rank the following catalog candidates against the user's taste and suggest only the best one. They are in the form of Title - Author, separated by newlines \n\n")
+
+	for _, book := range candidates {
+		sb.WriteString(fmt.Sprintf("%s - %s", book.Title, book.Author))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,19 @@
+package directives
+
+import (
+	"context"
+	"fmt"
+	"interestnaut/internal/session"
+)
+
+// GetRequestContext describes the circumstances the user is asking in, so the suggestion suits the moment
+// as well as their taste; returns an empty string when no context was given
+func GetRequestContext(_ context.Context, kind string, requestContext session.RequestContext) string {
+	if requestContext.IsEmpty() {
+		return ""
+	}
+
+	return fmt.Sprintf("The user is asking for a %s for right now, in this context: %s. "+
+		"Make sure the suggestion suits it, for example in length, tone and who it's appropriate for, "+
+		"and say how it does in the reason.\n", kind, requestContext)
+}
@@ -0,0 +1,51 @@
+package directives
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MediaHistory is what the user thinks of one kind of media, as descriptions such as "'Heat' by Michael Mann"
+type MediaHistory struct {
+	Media     string // e.g. "movies"
+	Favorites []string
+	Liked     []string
+	Disliked  []string
+}
+
+// GetCrossMediaContext describes the user's taste in other kinds of media so the model can carry it over
+// to the one being suggested; returns an empty string when there's nothing to go on
+func GetCrossMediaContext(_ context.Context, target string, histories []MediaHistory) string {
+	var sb strings.Builder
+	var media []string
+	for _, h := range histories {
+		if len(h.Favorites) == 0 && len(h.Liked) == 0 && len(h.Disliked) == 0 {
+			continue
+		}
+		media = append(media, h.Media)
+
+		sb.WriteString(fmt.Sprintf("\nThe user's %s:\n", h.Media))
+		writeList(&sb, "Favorites", h.Favorites)
+		writeList(&sb, "Liked suggestions", h.Liked)
+		writeList(&sb, "Disliked suggestions", h.Disliked)
+	}
+
+	if len(media) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("Base this suggestion on the user's taste in %s rather than only their %s. "+
+		"Look for the themes, moods, settings and styles they gravitate towards and find %s that share them, "+
+		"and name the items that led to it in the reason.\n", strings.Join(media, " and "), target, target) + sb.String()
+}
+
+func writeList(sb *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	sb.WriteString(heading + ":\n")
+	for _, item := range items {
+		sb.WriteString("- " + item + "\n")
+	}
+}
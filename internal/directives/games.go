@@ -20,6 +20,7 @@ IMPORTANT RULES:
   "title": "Game Name",
   "developer": "Developer's Name",
   "publisher": "Publisher's Name",
+  "year": "The year the game was first released, e.g. 1999",
   "primary_genre": "The primary genre of the game",
   "reason": "Detailed explanation of why this game matches their taste, referencing specific patterns in their library or likes/dislikes."
 }
@@ -29,6 +30,8 @@ IMPORTANT RULES:
 6. Refer to baseline for a list of games in the user's library.
 7. One suggestion per response.
 8. In the event of no historic data, suggest a game at random.
+9. Refer to user_constraints for the platforms the user owns; only suggest games released on at least one of them.
+10. Refer to user_constraints for the size of the user's backlog; the larger it is, the more a suggestion needs to earn its place, and shorter games are easier to fit in.
 
 Do not include any other text in your response, only the JSON object to be parsed.
 `
@@ -49,3 +52,41 @@ func GetGameBaseline(_ context.Context, initialList []session.VideoGame) string
 
 	return sb.String()
 }
+
+// GetGamePlatformContext tells the model which platforms the user can play on;
+// returns an empty string when the user hasn't set any
+func GetGamePlatformContext(_ context.Context, platforms []session.GamePlatform) string {
+	if len(platforms) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.Name
+	}
+
+	return fmt.Sprintf("The user owns the following platforms: %s. Only suggest games that are available on at least one of these platforms.", strings.Join(names, ", "))
+}
+
+// GetGameBacklogContext summarizes how many games the user still has to get through;
+// returns an empty string when the backlog is empty
+func GetGameBacklogContext(_ context.Context, backlog []session.BacklogEntry) string {
+	var pending, hours int
+	for _, e := range backlog {
+		if e.Status != session.Unplayed && e.Status != session.Playing {
+			continue
+		}
+		pending++
+		hours += e.Playtime
+	}
+
+	if pending == 0 {
+		return ""
+	}
+
+	if hours == 0 {
+		return fmt.Sprintf("The user has %d games in their backlog that they have not finished yet.", pending)
+	}
+
+	return fmt.Sprintf("The user has %d games in their backlog that they have not finished yet, totalling roughly %d hours of play.", pending, hours)
+}
@@ -20,6 +20,7 @@ IMPORTANT RULES:
   "title": "Movie Name",
   "director": "Director's Name",
   "writer": "Writer's Name",
+  "year": "The year the movie was first released, e.g. 1999",
   "primary_genre": "The primary genre of the movie",
   "reason": "Detailed explanation of why this movie matches their taste, referencing specific patterns in their library or likes/dislikes."
 }
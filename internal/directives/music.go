@@ -3,9 +3,13 @@ package directives
 import (
 	"context"
 	"fmt"
+	"interestnaut/internal/session"
 	"interestnaut/internal/spotify"
 	"log"
+	"math"
+	"sort"
 	"strings"
+	"time"
 )
 
 // MusicDirective is static, and will be used once to prime the model
@@ -27,48 +31,313 @@ IMPORTANT RULES:
 3. Don't suggest songs that are already in the user's library. 
 4. Refer to suggestions for your previous suggestions.
 5. Refer to user_constraints for any specific user-defined constraints.
-6. Refer to baseline for a list of tracks in the user's library.
+6. Refer to baseline for a weighted summary of the user's listening, followed by a list of tracks in the user's library.
 7. One suggestion per response.
 8. In the event of no historic data, suggest a music at random.
 
 Do not include any other text in your response, only the JSON object to be parsed.
 `
 
-// GetMusicBaseline generates a music baseline for the user based on their concatenated liked tracks
-func GetMusicBaseline(ctx context.Context, client spotify.Client) string {
-	tracks, err := client.GetAllLikedTracks(ctx)
-	if err != nil {
-		log.Printf("failed to get liked tracks: %v", err)
-		return ""
+// MusicAlbumDirective primes the model to suggest whole albums, for users who listen that way
+const MusicAlbumDirective = `
+You are a music recommendation assistant. Your goal is to understand the user's 
+music preferences and suggest albums they might enjoy listening to from start to finish. 
+Keep track of their likes and dislikes to improve your recommendations over time.
+
+IMPORTANT RULES:
+1. Never suggest the same album twice.
+2. Return only a valid JSON object with keys and string values properly enclosed in double quotes. Do not include any extra text, markdown fences, or commentary.
+{
+  "album": "Album Name",
+  "artist": "Artist Name",
+  "primary_genre": "The primary genre of the album",
+  "reason": "Detailed explanation of why this album matches their taste, referencing specific patterns in their library or likes/dislikes."
+}
+3. Don't suggest albums that most of the user's library is already drawn from. 
+4. Refer to suggestions for your previous suggestions.
+5. Refer to user_constraints for any specific user-defined constraints.
+6. Refer to baseline for a weighted summary of the user's listening, followed by a list of tracks in the user's library.
+7. One suggestion per response.
+8. Prefer studio albums over compilations, live albums and singles, unless the user asks otherwise.
+9. In the event of no historic data, suggest an album at random.
+
+Do not include any other text in your response, only the JSON object to be parsed.
+`
+
+// MusicArtistDirective primes the model to suggest artists to explore, rather than individual songs
+const MusicArtistDirective = `
+You are a music recommendation assistant. Your goal is to understand the user's 
+music preferences and suggest new artists they might enjoy exploring. 
+Keep track of their likes and dislikes to improve your recommendations over time.
+
+IMPORTANT RULES:
+1. Never suggest the same artist twice.
+2. Return only a valid JSON object with keys and string values properly enclosed in double quotes. Do not include any extra text, markdown fences, or commentary.
+{
+  "artist": "Artist Name",
+  "primary_genre": "The primary genre of the artist",
+  "reason": "Detailed explanation of why this artist matches their taste, referencing specific patterns in their library or likes/dislikes, and where to start with their music."
+}
+3. Don't suggest artists that already appear in the user's library. 
+4. Refer to suggestions for your previous suggestions.
+5. Refer to user_constraints for any specific user-defined constraints.
+6. Refer to baseline for a weighted summary of the user's listening, followed by a list of tracks in the user's library.
+7. One suggestion per response.
+8. In the event of no historic data, suggest an artist at random.
+
+Do not include any other text in your response, only the JSON object to be parsed.
+`
+
+// GetMusicDirective returns the directive for suggesting music at the given unit
+func GetMusicDirective(unit session.MusicUnit) string {
+	switch unit {
+	case session.AlbumUnit:
+		return MusicAlbumDirective
+	case session.ArtistUnit:
+		return MusicArtistDirective
+	default:
+		return MusicDirective
+	}
+}
+
+const (
+	topItemsLimit       = 50
+	recentlyPlayedLimit = 50
+	tasteSummarySize    = 25
+	// genreArtistsLimit caps how many of the heaviest artists are looked up for genres
+	genreArtistsLimit = 250
+	genreSummarySize  = 15
+	// maxLibraryTracks caps the liked tracks listed verbatim; the summaries cover the rest
+	maxLibraryTracks = 300
+)
+
+// Weights for each listening signal; a play or a top ranking says far more about current taste than a like
+var (
+	timeRangeWeights = map[spotify.TimeRange]float64{
+		spotify.ShortTerm:  3,
+		spotify.MediumTerm: 2,
+		spotify.LongTerm:   1,
+	}
+	recentPlayWeight   = 1.0
+	recentLikeWeight   = 0.5 // Saved within the last year
+	olderLikeWeight    = 0.1
+	recentLikeDuration = 365 * 24 * time.Hour
+)
+
+// GetMusicBaseline generates a music baseline for the user, led by a weighted summary of what they
+// actually listen to, followed by their liked tracks, newest first
+func GetMusicBaseline(ctx context.Context, client spotify.Client, tracks []spotify.SavedTrackItem) string {
+	artistWeights := make(map[string]float64)
+	artistIDs := make(map[string]string)
+	trackWeights := make(map[string]float64)
+	addTrack := func(track *spotify.Track, weight float64) {
+		if track == nil {
+			return
+		}
+		trackWeights[formatTrack(track)] += weight
+		for _, artist := range track.Artists {
+			artistWeights[artist.Name] += weight
+			artistIDs[artist.Name] = artist.ID
+		}
+	}
+
+	// Top items are ranked, so earlier entries count for more
+	for timeRange, weight := range timeRangeWeights {
+		if top, err := client.GetTopArtists(ctx, timeRange, topItemsLimit); err != nil {
+			log.Printf("failed to get top artists (%s): %v", timeRange, err)
+		} else {
+			for i, artist := range top.Items {
+				artistWeights[artist.Name] += weight * rankWeight(i, len(top.Items))
+				artistIDs[artist.Name] = artist.ID
+			}
+		}
+
+		if top, err := client.GetTopTracks(ctx, timeRange, topItemsLimit); err != nil {
+			log.Printf("failed to get top tracks (%s): %v", timeRange, err)
+		} else {
+			for i, track := range top.Items {
+				addTrack(track, weight*rankWeight(i, len(top.Items)))
+			}
+		}
+	}
+
+	if recent, err := client.GetRecentlyPlayed(ctx, recentlyPlayedLimit); err != nil {
+		log.Printf("failed to get recently played tracks: %v", err)
+	} else {
+		for _, item := range recent.Items {
+			addTrack(item.Track, recentPlayWeight)
+		}
+	}
+
+	for _, item := range tracks {
+		weight := olderLikeWeight
+		if added, err := time.Parse(time.RFC3339, item.AddedAt); err == nil && time.Since(added) < recentLikeDuration {
+			weight = recentLikeWeight
+		}
+		addTrack(item.Track, weight)
 	}
 
-	if len(tracks) == 0 {
-		log.Println("no liked tracks found")
+	if len(tracks) == 0 && len(trackWeights) == 0 {
+		log.Println("no liked or played tracks found")
 		return ""
 	}
 
 	var sb strings.Builder
-	sb.WriteString("Here are all the tracks in the user's library. Use these to understand their music taste and suggest new songs they might enjoy. They are in the form of Artist - Song (Album), separated by newlines \n\n")
+	sb.WriteString("Here is a summary of what the user listens to most, weighted by how heavily and how recently they play it. The weight is out of 100, and is the strongest signal of their current taste.\n\n")
+
+	if genres := genreHistogram(ctx, client, artistWeights, artistIDs); genres != "" {
+		sb.WriteString(fmt.Sprintf("Genres: %s\n\n", genres))
+	}
+
+	sb.WriteString("Top artists:\n")
+	for _, w := range topWeighted(artistWeights, tasteSummarySize) {
+		sb.WriteString(fmt.Sprintf("%s (%d)\n", w.name, w.weight))
+	}
+
+	sb.WriteString("\nTop tracks, in the form of Artist - Song (Album):\n")
+	for _, w := range topWeighted(trackWeights, tasteSummarySize) {
+		sb.WriteString(fmt.Sprintf("%s (%d)\n", w.name, w.weight))
+	}
+
+	sb.WriteString("\nHere are the tracks the user most recently added to their library. These are a weaker signal, as some may have been liked once long ago. They are in the form of Artist - Song (Album), separated by newlines \n\n")
 
 	// Create a more compact representation to save tokens
 	// Format: "Artist - Song (Album)" one per line
-	for _, item := range tracks {
+	// Saved tracks come newest first, and the summaries above already account for the rest
+	for i, item := range tracks {
+		if i == maxLibraryTracks {
+			sb.WriteString(fmt.Sprintf("...and %d older tracks\n", len(tracks)-maxLibraryTracks))
+			break
+		}
 		if item.Track != nil {
-			var artistNames []string
-			for _, artist := range item.Track.Artists {
-				artistNames = append(artistNames, artist.Name)
-			}
-			sb.WriteString(fmt.Sprintf("%s - %s",
-				strings.Join(artistNames, ", "),
-				item.Track.Name))
-			if item.Track.Album.Name != "" {
-				sb.WriteString(fmt.Sprintf(" (%s)", item.Track.Album.Name))
-			}
+			sb.WriteString(formatTrack(item.Track))
 			sb.WriteString("\n")
 		}
 	}
 
-	sb.WriteString(fmt.Sprintf("\nAnalyzed %d tracks. Based on these, suggest songs that match their musical preferences while introducing new artists and styles. For each suggestion, explain why you think they'll like it based on specific patterns in their library.\n", len(tracks)))
+	sb.WriteString(fmt.Sprintf("\nAnalyzed %d tracks. Based on these, suggest songs that match their musical preferences while introducing new artists and styles. For each suggestion, explain why you think they'll like it based on specific patterns in their listening.\n", len(tracks)))
 
 	return sb.String()
 }
+
+// formatTrack renders a track as "Artist - Song (Album)"
+func formatTrack(track *spotify.Track) string {
+	var artistNames []string
+	for _, artist := range track.Artists {
+		artistNames = append(artistNames, artist.Name)
+	}
+
+	formatted := fmt.Sprintf("%s - %s", strings.Join(artistNames, ", "), track.Name)
+	if track.Album.Name != "" {
+		formatted += fmt.Sprintf(" (%s)", track.Album.Name)
+	}
+	return formatted
+}
+
+// genreHistogram looks up the genres of the heaviest artists and summarizes them as percentages,
+// e.g. "32% shoegaze, 18% post-rock"; returns an empty string if no genres could be found
+func genreHistogram(ctx context.Context, client spotify.Client, artistWeights map[string]float64, artistIDs map[string]string) string {
+	names := make([]string, 0, len(artistWeights))
+	for name := range artistWeights {
+		if artistIDs[name] != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return artistWeights[names[i]] > artistWeights[names[j]]
+	})
+	if len(names) > genreArtistsLimit {
+		names = names[:genreArtistsLimit]
+	}
+
+	ids := make([]string, len(names))
+	for i, name := range names {
+		ids[i] = artistIDs[name]
+	}
+
+	artists, err := client.GetArtists(ctx, ids)
+	if err != nil {
+		log.Printf("failed to get artist genres: %v", err)
+		return ""
+	}
+
+	return summarizeGenres(artists, artistWeights)
+}
+
+// summarizeGenres splits each artist's weight evenly across their genres, so an artist tagged with many
+// genres counts no more than one tagged with a few, and summarizes the result as percentages
+func summarizeGenres(artists []spotify.Artist, artistWeights map[string]float64) string {
+	genreWeights := make(map[string]float64)
+	var total float64
+	for _, artist := range artists {
+		if len(artist.Genres) == 0 {
+			continue
+		}
+		share := artistWeights[artist.Name] / float64(len(artist.Genres))
+		for _, genre := range artist.Genres {
+			genreWeights[genre] += share
+		}
+		total += artistWeights[artist.Name]
+	}
+
+	if total == 0 {
+		return ""
+	}
+
+	genres := make([]string, 0, len(genreWeights))
+	for genre := range genreWeights {
+		genres = append(genres, genre)
+	}
+	sort.Slice(genres, func(i, j int) bool {
+		if genreWeights[genres[i]] != genreWeights[genres[j]] {
+			return genreWeights[genres[i]] > genreWeights[genres[j]]
+		}
+		return genres[i] < genres[j]
+	})
+
+	var parts []string
+	for _, genre := range genres {
+		pct := int(math.Round(genreWeights[genre] / total * 100))
+		if len(parts) == genreSummarySize || pct < 1 {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%d%% %s", pct, genre))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// rankWeight scales linearly from 1 for the top ranked item down towards 0 for the last
+func rankWeight(rank, total int) float64 {
+	return float64(total-rank) / float64(total)
+}
+
+type weighted struct {
+	name   string
+	weight int
+}
+
+// topWeighted returns the n heaviest entries, with weights normalized so the heaviest is 100
+func topWeighted(weights map[string]float64, n int) []weighted {
+	var maxWeight float64
+	for _, w := range weights {
+		maxWeight = math.Max(maxWeight, w)
+	}
+
+	result := make([]weighted, 0, len(weights))
+	for name, w := range weights {
+		result = append(result, weighted{name: name, weight: int(math.Round(w / maxWeight * 100))})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].weight != result[j].weight {
+			return result[i].weight > result[j].weight
+		}
+		return result[i].name < result[j].name
+	})
+
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package directives
+
+import (
+	"interestnaut/internal/spotify"
+	"testing"
+)
+
+func TestSummarizeGenres(t *testing.T) {
+	tests := []struct {
+		name    string
+		artists []spotify.Artist
+		weights map[string]float64
+		want    string
+	}{
+		{
+			name: "many genres don't outweigh few",
+			artists: []spotify.Artist{
+				{Name: "Slowdive", Genres: []string{"shoegaze"}},
+				{Name: "Tagged", Genres: []string{"pop", "rock", "indie", "folk"}},
+			},
+			weights: map[string]float64{"Slowdive": 1, "Tagged": 1},
+			want:    "50% shoegaze, 13% folk, 13% indie, 13% pop, 13% rock",
+		},
+		{
+			name: "shared genre",
+			artists: []spotify.Artist{
+				{Name: "Slowdive", Genres: []string{"shoegaze", "dream pop"}},
+				{Name: "Beach House", Genres: []string{"dream pop"}},
+			},
+			weights: map[string]float64{"Slowdive": 2, "Beach House": 1},
+			want:    "67% dream pop, 33% shoegaze",
+		},
+		{
+			name: "artists without genres are left out",
+			artists: []spotify.Artist{
+				{Name: "Slowdive", Genres: []string{"shoegaze"}},
+				{Name: "Unknown"},
+			},
+			weights: map[string]float64{"Slowdive": 1, "Unknown": 5},
+			want:    "100% shoegaze",
+		},
+		{
+			name:    "no genres",
+			artists: []spotify.Artist{{Name: "Unknown"}},
+			weights: map[string]float64{"Unknown": 1},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeGenres(tt.artists, tt.weights); got != tt.want {
+				t.Errorf("summarizeGenres() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
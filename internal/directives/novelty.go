@@ -0,0 +1,22 @@
+package directives
+
+import (
+	"context"
+	"fmt"
+	"interestnaut/internal/session"
+)
+
+// GetNoveltyContext steers how far from the familiar a suggestion should stray; returns an empty string
+// when there's no preference
+func GetNoveltyContext(_ context.Context, kind string, novelty session.Novelty) string {
+	switch novelty {
+	case session.Familiar:
+		return fmt.Sprintf("The user wants a safe pick: suggest a well-known, widely loved %s, ideally by a creator "+
+			"or in a genre they already enjoy. Avoid obscure or divisive choices.\n", kind)
+	case session.Adventurous:
+		return fmt.Sprintf("The user wants a deep cut: suggest a lesser-known %s that few people have heard of, "+
+			"ideally by a creator and in a genre that don't appear in their library or past suggestions. "+
+			"Avoid blockbusters, bestsellers and chart hits, and explain in the reason how it still connects to their taste.\n", kind)
+	}
+	return ""
+}
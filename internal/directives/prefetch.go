@@ -0,0 +1,18 @@
+package directives
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetQueuedSuggestionsContext lists suggestions that are lined up to be shown but not yet recorded in the
+// session, so the next one doesn't repeat them; returns an empty string when nothing is queued
+func GetQueuedSuggestionsContext(_ context.Context, queued []string) string {
+	if len(queued) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("These suggestions are already lined up to be shown to the user next, so suggest "+
+		"something different from all of them: %s.\n", strings.Join(queued, "; "))
+}
@@ -0,0 +1,54 @@
+package directives
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetTasteProfileTask primes the model to write a taste profile from the baseline, past suggestions and
+// their outcomes, instead of making a suggestion
+func GetTasteProfileTask(_ context.Context, media string) string {
+	return fmt.Sprintf(`
+You are helping a recommendation assistant understand a user's taste in %[1]s.
+Below are the user's library, the suggestions they've been given with how they responded to each, and any
+refinements they asked for. Write a taste profile describing what they enjoy and what they don't.
+
+IMPORTANT RULES:
+1. Write plain text in the second person, addressed to the user, e.g. "You gravitate towards...". Do not use JSON or markdown.
+2. Cover the genres, styles, moods, eras and creators they favor, and what they've disliked or skipped.
+3. Be specific, naming the patterns behind their likes rather than listing titles.
+4. Keep it under 250 words; it will be sent in place of their full history with every future suggestion.
+5. If user_constraints include a previous profile, update it with what's been learned since rather than starting over.
+6. If there's little to go on yet, say so briefly rather than guessing.
+
+Do not include any other text in your response, only the profile.
+`, media)
+}
+
+// GetPreviousTasteProfileContext gives the model the profile being refreshed. A profile the user edited is
+// their own description of their taste, so what they wrote is kept.
+func GetPreviousTasteProfileContext(_ context.Context, profile string, edited bool) string {
+	if edited {
+		return fmt.Sprintf("This is the previous profile, which the user edited themselves. Keep everything they "+
+			"wrote unless their feedback since clearly contradicts it, and only add what's been learned since:\n\n%s\n", profile)
+	}
+
+	return fmt.Sprintf("This is the previous profile:\n\n%s\n", profile)
+}
+
+// GetTasteProfileBaseline stands in for the baseline once there's a profile, listing the suggestions it
+// covers by name so they aren't repeated
+func GetTasteProfileBaseline(_ context.Context, media, profile string, covered []string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Here is a profile of the user's taste in %s, summarized from their library and "+
+		"feedback so far. Use it to understand their preferences and suggest something new they might enjoy.\n\n", media))
+	sb.WriteString(profile)
+	sb.WriteString("\n")
+
+	if len(covered) > 0 {
+		sb.WriteString(fmt.Sprintf("\nYou have already suggested these, so never suggest them again: %s.\n", strings.Join(covered, "; ")))
+	}
+
+	return sb.String()
+}
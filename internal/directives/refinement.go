@@ -0,0 +1,14 @@
+package directives
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetRefinementContext asks for a follow-up to a suggestion, steered by the user's own words,
+// e.g. "like this, but darker"
+func GetRefinementContext(_ context.Context, kind, from, instruction string) string {
+	return fmt.Sprintf("The user was just suggested the %s %s and wants something like it, but with this change: \"%s\". "+
+		"Suggest a different %s that keeps what fits their taste about %s while following their request, "+
+		"and explain how it does both in the reason.\n", kind, from, instruction, kind, from)
+}
@@ -20,6 +20,7 @@ IMPORTANT RULES:
   "title": "Show Name",
   "director": "Director's Name",
   "writer": "Writer's Name",
+  "year": "The year the show was first released, e.g. 1999",
   "primary_genre": "The primary genre of the show",
   "reason": "Detailed explanation of why this show matches their taste, referencing specific patterns in their library or likes/dislikes."
 }
@@ -29,6 +30,7 @@ IMPORTANT RULES:
 6. Refer to baseline for a list of shows in the user's library.
 7. One suggestion per response.
 8. In the event of no historic data, suggest a tv show at random.
+9. Refer to user_constraints for shows the user is currently watching; avoid pushing them to start several new series at once.
 
 Do not include any other text in your response, only the JSON object to be parsed.
 `
@@ -49,3 +51,30 @@ func GetTVBaseline(_ context.Context, initialList []session.TVShow) string {
 
 	return sb.String()
 }
+
+// GetTVProgressContext describes the shows the user is partway through, so the model can pace new series;
+// returns an empty string when nothing is in progress
+func GetTVProgressContext(_ context.Context, progress []session.TVProgress) string {
+	var inProgress []session.TVProgress
+	for _, p := range progress {
+		if p.Status == session.Watching || p.Status == session.Paused {
+			inProgress = append(inProgress, p)
+		}
+	}
+
+	if len(inProgress) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("The user is currently partway through these shows. They are in the form of Title - Season, Episode (status), separated by newlines \n\n")
+
+	for _, p := range inProgress {
+		sb.WriteString(fmt.Sprintf("%s - Season %d, Episode %d (%s)", p.Show.Title, p.Season, p.Episode, p.Status))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\nThe user has %d shows in progress. Don't suggest any of these, and favor limited or shorter series over another long-running commitment unless the user asks otherwise.\n", len(inProgress)))
+
+	return sb.String()
+}
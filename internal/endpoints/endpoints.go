@@ -0,0 +1,192 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	request "github.com/catlee993/go-request"
+)
+
+// Service identifies a remote API the app talks to
+type Service string
+
+const (
+	SpotifyAPI        Service = "spotify_api"
+	SpotifyAccounts   Service = "spotify_accounts"
+	TMDB              Service = "tmdb"
+	TMDBImages        Service = "tmdb_images"
+	RAWG              Service = "rawg"
+	OpenLibrary       Service = "openlibrary"
+	OpenLibraryCovers Service = "openlibrary_covers"
+	Gemini            Service = "gemini"
+	OpenAI            Service = "openai"
+)
+
+const (
+	// FileEnv overrides where the endpoints file is read from
+	FileEnv = "INTERESTNAUT_ENDPOINTS_FILE"
+	// envPrefix and envSuffix wrap the upper cased service name, e.g. INTERESTNAUT_TMDB_URL=http://localhost:8089
+	envPrefix = "INTERESTNAUT_"
+	envSuffix = "_URL"
+)
+
+// Endpoint is where a service is reached. Port 0 means the scheme's default.
+type Endpoint struct {
+	Scheme string `json:"scheme"`
+	Host   string `json:"host"`
+	Port   int    `json:"port"`
+}
+
+// Address returns the host, with the port when one is set
+func (e Endpoint) Address() string {
+	if e.Port == 0 {
+		return e.Host
+	}
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+// URL returns the endpoint as a base URL without a trailing slash, e.g. "http://localhost:8089"
+func (e Endpoint) URL() string {
+	return fmt.Sprintf("%s://%s", e.Scheme, e.Address())
+}
+
+// RequestScheme returns the scheme for building a go-request requester; local stand-ins usually use plain HTTP
+func (e Endpoint) RequestScheme() request.Scheme {
+	if e.Scheme == "http" {
+		return request.HTTP
+	}
+	return request.HTTPS
+}
+
+var defaults = map[Service]Endpoint{
+	SpotifyAPI:        {Scheme: "https", Host: "api.spotify.com"},
+	SpotifyAccounts:   {Scheme: "https", Host: "accounts.spotify.com"},
+	TMDB:              {Scheme: "https", Host: "api.themoviedb.org"},
+	TMDBImages:        {Scheme: "https", Host: "image.tmdb.org"},
+	RAWG:              {Scheme: "https", Host: "api.rawg.io"},
+	OpenLibrary:       {Scheme: "https", Host: "openlibrary.org"},
+	OpenLibraryCovers: {Scheme: "https", Host: "covers.openlibrary.org"},
+	Gemini:            {Scheme: "https", Host: "generativelanguage.googleapis.com"},
+	OpenAI:            {Scheme: "https", Host: "api.openai.com"},
+}
+
+var (
+	resolved map[Service]Endpoint
+	once     sync.Once
+)
+
+// Get returns the endpoint for a service. Overrides are read once, with environment variables taking
+// precedence over the endpoints file, which takes precedence over the real services.
+func Get(service Service) Endpoint {
+	once.Do(load)
+	return resolved[service]
+}
+
+// load resolves every endpoint from the defaults, the endpoints file and the environment
+func load() {
+	resolved = make(map[Service]Endpoint, len(defaults))
+	for service, endpoint := range defaults {
+		resolved[service] = endpoint
+	}
+
+	overrides, err := readFile()
+	if err != nil {
+		log.Printf("WARNING: Failed to read endpoints file, ignoring it: %v", err)
+	}
+	for service, endpoint := range overrides {
+		apply(service, endpoint)
+	}
+
+	for service := range defaults {
+		raw := os.Getenv(envPrefix + strings.ToUpper(string(service)) + envSuffix)
+		if raw == "" {
+			continue
+		}
+		endpoint, err := parse(raw)
+		if err != nil {
+			log.Printf("WARNING: Ignoring endpoint override for %s: %v", service, err)
+			continue
+		}
+		apply(service, endpoint)
+	}
+}
+
+// apply overrides a known service's endpoint, keeping the default for any field left empty
+func apply(service Service, override Endpoint) {
+	endpoint, ok := resolved[service]
+	if !ok {
+		log.Printf("WARNING: Ignoring endpoint override for unknown service %s", service)
+		return
+	}
+
+	if override.Scheme != "" {
+		endpoint.Scheme = strings.ToLower(override.Scheme)
+	}
+	if override.Host != "" {
+		endpoint.Host = override.Host
+	}
+	if override.Port != 0 {
+		endpoint.Port = override.Port
+	}
+
+	log.Printf("Using %s for %s", endpoint.URL(), service)
+	resolved[service] = endpoint
+}
+
+// readFile reads overrides from the endpoints file, keyed by service. A missing file isn't an error.
+func readFile() (map[Service]Endpoint, error) {
+	path := os.Getenv(FileEnv)
+	if path == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		path = filepath.Join(homeDir, ".interestnaut", "endpoints.json")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read endpoints file: %w", err)
+	}
+
+	var overrides map[Service]Endpoint
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal endpoints file: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// parse reads an endpoint from a URL such as "http://localhost:8089"
+func parse(raw string) (Endpoint, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Endpoint{}, fmt.Errorf("unsupported scheme in %q", raw)
+	}
+	if u.Hostname() == "" {
+		return Endpoint{}, fmt.Errorf("missing host in %q", raw)
+	}
+
+	endpoint := Endpoint{Scheme: u.Scheme, Host: u.Hostname()}
+	if port := u.Port(); port != "" {
+		if endpoint.Port, err = strconv.Atoi(port); err != nil {
+			return Endpoint{}, fmt.Errorf("invalid port in %q: %w", raw, err)
+		}
+	}
+
+	return endpoint, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"interestnaut/internal/creds"
+	"interestnaut/internal/endpoints"
 	"interestnaut/internal/llm"
 	"interestnaut/internal/session"
 	"log"
@@ -85,6 +86,14 @@ func (c *client[T]) ComposeMessages(_ context.Context, content *session.Content[
 		})
 	}
 
+	// Add refinements the user asked for
+	for _, refinement := range content.Refinements {
+		msgs = append(msgs, &Message{
+			Role:    RoleUser,
+			Content: formatRefinement(refinement),
+		})
+	}
+
 	// Add user constraints
 	for _, constraint := range content.UserConstraints {
 		msgs = append(msgs, &Message{
@@ -98,6 +107,33 @@ func (c *client[T]) ComposeMessages(_ context.Context, content *session.Content[
 
 // SendMessages implements the llm.Client interface
 func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm.SuggestionResponse[T], error) {
+	// Get the raw response, kept on the suggestion for error followups
+	rawResponse, err := c.Complete(ctx, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract clean JSON content
+	jsonContent := extractJsonContent(rawResponse)
+
+	// Parse the response into our generic type
+	suggestion, err := llm.ParseSuggestionFromString[T](jsonContent)
+	if err != nil {
+		errSuggest := &llm.SuggestionResponse[T]{
+			RawResponse: rawResponse,
+		}
+		log.Printf("WARNING: Failed to parse JSON response: %v. Content: %s", err, jsonContent)
+		return errSuggest, fmt.Errorf("failed to parse suggestion: %w", err)
+	}
+
+	// Store the raw response in the suggestion
+	suggestion.RawResponse = rawResponse
+
+	return suggestion, nil
+}
+
+// Complete implements the llm.Client interface
+func (c *client[T]) Complete(ctx context.Context, msgs ...llm.Message) (string, error) {
 	// Convert messages to Gemini format
 	contents := make([]map[string]interface{}, 0, len(msgs))
 
@@ -124,7 +160,7 @@ func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Get the current model from settings if available
@@ -135,9 +171,9 @@ func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm
 
 	// Create request to Gemini API
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.Gemini).RequestScheme()),
 		request.WithMethod(request.Post),
-		request.WithHost("generativelanguage.googleapis.com"),
+		request.WithHost(endpoints.Get(endpoints.Gemini).Address()),
 		request.WithPath("v1", "models", modelToUse+":generateContent"),
 		request.WithQueryArgs(map[string][]string{
 			"key": {c.apiKey},
@@ -148,17 +184,17 @@ func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm
 		}),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	var geminiResp ChatResponse
 	_, err = req.Make(ctx, &geminiResp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get suggestion from Gemini: %w", err)
+		return "", fmt.Errorf("failed to get suggestion from Gemini: %w", err)
 	}
 
 	if len(geminiResp.Candidates) == 0 {
-		return nil, fmt.Errorf("no response candidates available")
+		return "", fmt.Errorf("no response candidates available")
 	}
 
 	// Get text content from the response
@@ -167,26 +203,7 @@ func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm
 		contentText = geminiResp.Candidates[0].Content.Parts[0].Text
 	}
 
-	// Extract clean JSON content
-	jsonContent := extractJsonContent(contentText)
-
-	// Store the original raw response
-	rawResponse := contentText
-
-	// Parse the response into our generic type
-	suggestion, err := llm.ParseSuggestionFromString[T](jsonContent)
-	if err != nil {
-		errSuggest := &llm.SuggestionResponse[T]{
-			RawResponse: rawResponse,
-		}
-		log.Printf("WARNING: Failed to parse JSON response: %v. Content: %s", err, jsonContent)
-		return errSuggest, fmt.Errorf("failed to parse suggestion: %w", err)
-	}
-
-	// Store the raw response in the suggestion
-	suggestion.RawResponse = rawResponse
-
-	return suggestion, nil
+	return contentText, nil
 }
 
 // ErrorFollowup implements the llm.Client interface
@@ -220,6 +237,14 @@ func (c *client[T]) ErrorFollowup(ctx context.Context, resp *llm.SuggestionRespo
 }
 
 func formatSuggestion[T session.Media](suggestion session.Suggestion[T]) string {
+	formatted := formatSuggestionContent(suggestion)
+	if suggestion.Context != nil {
+		formatted += fmt.Sprintf("\nAsked for in context: %s", suggestion.Context)
+	}
+	return formatted
+}
+
+func formatSuggestionContent[T session.Media](suggestion session.Suggestion[T]) string {
 	switch media := any(suggestion.Content).(type) {
 	case session.Music:
 		return fmt.Sprintf("Suggested song:\nTitle: %s\nArtist: %s\nAlbum: %s\nUser Outcome: %s",
@@ -240,3 +265,8 @@ func formatSuggestion[T session.Media](suggestion session.Suggestion[T]) string
 		return fmt.Sprintf("Reasoning: %s", suggestion.Reasoning)
 	}
 }
+
+func formatRefinement(refinement session.Refinement) string {
+	return fmt.Sprintf("User refinement:\nAfter: %s\nAsked for: %s\nGot: %s",
+		refinement.From, refinement.Instruction, refinement.Result)
+}
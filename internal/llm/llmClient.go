@@ -9,4 +9,6 @@ type Client[T session.Media] interface {
 	ComposeMessages(context.Context, *session.Content[T]) ([]Message, error)
 	SendMessages(context.Context, ...Message) (*SuggestionResponse[T], error)
 	ErrorFollowup(context.Context, *SuggestionResponse[T], ...Message) (*SuggestionResponse[T], error)
+	// Complete sends messages and returns the reply as is, for requests that aren't for a suggestion
+	Complete(context.Context, ...Message) (string, error)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"interestnaut/internal/session"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -17,12 +18,36 @@ type SuggestionResponse[T session.Media] struct {
 	Title        string `json:"title"`
 	Artist       string `json:"artist"`
 	Album        string `json:"album"`
+	Year         Year   `json:"year,omitempty"` // First released, used to verify the suggestion against its catalog
 	PrimaryGenre string `json:"primary_genre"`
 	Reason       string `json:"reason"`
 	Content      T      `json:"content"`
 	RawResponse  string `json:"-"` // Store the original unparsed response
 }
 
+// Year is when something was first released, which models give as either a number or a string
+type Year string
+
+// UnmarshalJSON accepts the year as a JSON number or string, leaving it empty for null
+func (y *Year) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		*y = ""
+	case string:
+		*y = Year(strings.TrimSpace(v))
+	case float64:
+		*y = Year(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("year must be a number or a string, got %s", data)
+	}
+	return nil
+}
+
 // ParseSuggestionFromString attempts to parse a suggestion from a string response
 // If JSON parsing fails, it tries to infer the properties from the text
 func ParseSuggestionFromString[T session.Media](content string) (*SuggestionResponse[T], error) {
@@ -156,6 +181,11 @@ func ParseSuggestionFromString[T session.Media](content string) (*SuggestionResp
 		}
 	}
 
+	// Look for release year
+	if yearMatch := regexp.MustCompile(`"?year"?\s*[:=]\s*"?(\d{4})`).FindStringSubmatch(content); len(yearMatch) > 1 {
+		suggestion.Year = Year(yearMatch[1])
+	}
+
 	// Look for primary genre
 	if genreMatch := regexp.MustCompile(`"?primary_genre"?\s*[:=]\s*"([^"]+)"`).FindStringSubmatch(content); len(genreMatch) > 1 {
 		suggestion.PrimaryGenre = genreMatch[1]
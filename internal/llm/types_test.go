@@ -0,0 +1,61 @@
+package llm
+
+import (
+	"interestnaut/internal/session"
+	"testing"
+)
+
+func TestParseSuggestionFromString(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantYear     Year
+		wantDirector string
+	}{
+		{
+			name:         "numeric year",
+			content:      `{"title": "The Matrix", "year": 1999, "content": {"title": "The Matrix", "director": "The Wachowskis"}}`,
+			wantYear:     "1999",
+			wantDirector: "The Wachowskis",
+		},
+		{
+			name:         "string year",
+			content:      `{"title": "The Matrix", "year": "1999", "content": {"title": "The Matrix", "director": "The Wachowskis"}}`,
+			wantYear:     "1999",
+			wantDirector: "The Wachowskis",
+		},
+		{
+			name:         "null year",
+			content:      `{"title": "The Matrix", "year": null, "content": {"title": "The Matrix", "director": "The Wachowskis"}}`,
+			wantDirector: "The Wachowskis",
+		},
+		{
+			name:         "no year",
+			content:      `{"title": "The Matrix", "content": {"title": "The Matrix", "director": "The Wachowskis"}}`,
+			wantDirector: "The Wachowskis",
+		},
+		{
+			name:     "not JSON",
+			content:  `title: "The Matrix", year: 1999`,
+			wantYear: "1999",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSuggestionFromString[session.Movie](tt.content)
+			if err != nil {
+				t.Fatalf("ParseSuggestionFromString() failed: %v", err)
+			}
+			if got.Title != "The Matrix" {
+				t.Errorf("Title = %q, want %q", got.Title, "The Matrix")
+			}
+			if got.Year != tt.wantYear {
+				t.Errorf("Year = %q, want %q", got.Year, tt.wantYear)
+			}
+			if got.Content.Director != tt.wantDirector {
+				t.Errorf("Content.Director = %q, want %q", got.Content.Director, tt.wantDirector)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"interestnaut/internal/creds"
+	"interestnaut/internal/endpoints"
 	"interestnaut/internal/llm"
 	"interestnaut/internal/session"
 	"log"
@@ -77,6 +78,9 @@ func (c *client[T]) ComposeMessages(_ context.Context, content *session.Content[
 	for _, suggestion := range content.Suggestions {
 		msg.Content += "\n" + formatSuggestion(suggestion)
 	}
+	for _, refinement := range content.Refinements {
+		msg.Content += "\n" + formatRefinement(refinement)
+	}
 	msgs := []llm.Message{msg}
 
 	for _, constraint := range content.UserConstraints {
@@ -90,6 +94,32 @@ func (c *client[T]) ComposeMessages(_ context.Context, content *session.Content[
 }
 
 func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm.SuggestionResponse[T], error) {
+	// Get the raw response, kept on the suggestion for error followups
+	rawResponse, err := c.Complete(ctx, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract clean JSON content
+	content := extractJsonContent(rawResponse)
+
+	// Parse the response into our generic type
+	suggestion, err := llm.ParseSuggestionFromString[T](content)
+	if err != nil {
+		errSuggest := &llm.SuggestionResponse[T]{
+			RawResponse: rawResponse,
+		}
+		log.Printf("WARNING: Failed to parse JSON response: %v. Content: %s", err, content)
+		return errSuggest, fmt.Errorf("failed to parse suggestion: %w", err)
+	}
+
+	// Store the raw response in the suggestion
+	suggestion.RawResponse = rawResponse
+
+	return suggestion, nil
+}
+
+func (c *client[T]) Complete(ctx context.Context, msgs ...llm.Message) (string, error) {
 	// Get the current model from settings if available
 	modelToUse := defaultModel
 	if c.cm != nil && c.cm.Settings() != nil {
@@ -106,13 +136,13 @@ func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.OpenAI).RequestScheme()),
 		request.WithMethod(request.Post),
-		request.WithHost("api.openai.com"),
+		request.WithHost(endpoints.Get(endpoints.OpenAI).Address()),
 		request.WithPath("v1", "chat", "completions"),
 		request.WithBody(jsonData),
 		request.WithHeaders(map[string][]string{
@@ -121,7 +151,7 @@ func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm
 		}),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	var chatResp ChatResponse
@@ -138,36 +168,17 @@ func (c *client[T]) SendMessages(ctx context.Context, msgs ...llm.Message) (*llm
 				} `json:"error"`
 			}
 			if jsonErr := json.Unmarshal([]byte(err.Error()), &apiError); jsonErr == nil && apiError.Error.Message != "" {
-				return nil, fmt.Errorf("failed to get suggestion from LLM: %s", apiError.Error.Message)
+				return "", fmt.Errorf("failed to get suggestion from LLM: %s", apiError.Error.Message)
 			}
 		}
-		return nil, fmt.Errorf("failed to get suggestion from LLM: %w", err)
+		return "", fmt.Errorf("failed to get suggestion from LLM: %w", err)
 	}
 
 	if len(chatResp.Choices) == 0 {
-		return nil, fmt.Errorf("no response choices available")
+		return "", fmt.Errorf("no response choices available")
 	}
 
-	// Extract clean JSON content
-	content := extractJsonContent(chatResp.Choices[0].Message.GetContent())
-
-	// Store the original raw response
-	rawResponse := chatResp.Choices[0].Message.GetContent()
-
-	// Parse the response into our generic type
-	suggestion, err := llm.ParseSuggestionFromString[T](content)
-	if err != nil {
-		errSuggest := &llm.SuggestionResponse[T]{
-			RawResponse: rawResponse,
-		}
-		log.Printf("WARNING: Failed to parse JSON response: %v. Content: %s", err, content)
-		return errSuggest, fmt.Errorf("failed to parse suggestion: %w", err)
-	}
-
-	// Store the raw response in the suggestion
-	suggestion.RawResponse = rawResponse
-
-	return suggestion, nil
+	return chatResp.Choices[0].Message.GetContent(), nil
 }
 
 func (c *client[T]) ErrorFollowup(ctx context.Context, resp *llm.SuggestionResponse[T], msgs ...llm.Message) (*llm.SuggestionResponse[T], error) {
@@ -200,6 +211,14 @@ func (c *client[T]) ErrorFollowup(ctx context.Context, resp *llm.SuggestionRespo
 }
 
 func formatSuggestion[T session.Media](suggestion session.Suggestion[T]) string {
+	formatted := formatSuggestionContent(suggestion)
+	if suggestion.Context != nil {
+		formatted += fmt.Sprintf("\nAsked for in context: %s", suggestion.Context)
+	}
+	return formatted
+}
+
+func formatSuggestionContent[T session.Media](suggestion session.Suggestion[T]) string {
 	switch media := any(suggestion.Content).(type) {
 	case session.Music:
 		return fmt.Sprintf("Suggested song:\nTitle: %s\nArtist: %s\nAlbum: %s\nUser Outcome: %s",
@@ -220,3 +239,8 @@ func formatSuggestion[T session.Media](suggestion session.Suggestion[T]) string
 		return fmt.Sprintf("Reasoning: %s", suggestion.Reasoning)
 	}
 }
+
+func formatRefinement(refinement session.Refinement) string {
+	return fmt.Sprintf("User refinement:\nAfter: %s\nAsked for: %s\nGot: %s",
+		refinement.From, refinement.Instruction, refinement.Result)
+}
@@ -4,19 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"interestnaut/internal/endpoints"
 	"interestnaut/internal/session"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	baseURL          = "https://openlibrary.org"
-	searchEndpoint   = "/search.json"
-	bookEndpoint     = "/works"
-	authorEndpoint   = "/authors"
-	coverURLTemplate = "https://covers.openlibrary.org/b/id/%s-L.jpg"
+	searchEndpoint    = "/search.json"
+	bookEndpoint      = "/works"
+	authorEndpoint    = "/authors"
+	isbnEndpoint      = "/isbn"
+	editionsEndpoint  = "/editions.json"
+	subjectEndpoint   = "/subjects"
+	coverPathTemplate = "/b/id/%d-L.jpg"
 )
 
 // SearchResult represents a book search result from Open Library
@@ -63,6 +67,54 @@ type AuthorResponse struct {
 	PersonalWeb string `json:"personal_web,omitempty"`
 }
 
+// Edition represents a single published edition of a work
+type Edition struct {
+	Title          string   `json:"title"`
+	Subtitle       string   `json:"subtitle,omitempty"`
+	Key            string   `json:"key"`
+	Publishers     []string `json:"publishers,omitempty"`
+	PublishDate    string   `json:"publish_date,omitempty"`
+	NumberOfPages  int      `json:"number_of_pages,omitempty"`
+	PhysicalFormat string   `json:"physical_format,omitempty"`
+	ISBN10         []string `json:"isbn_10,omitempty"`
+	ISBN13         []string `json:"isbn_13,omitempty"`
+	Covers         []int    `json:"covers,omitempty"`
+	Works          []struct {
+		Key string `json:"key"`
+	} `json:"works,omitempty"`
+	Authors []struct {
+		Key string `json:"key"`
+	} `json:"authors,omitempty"`
+}
+
+// EditionsResponse represents the editions listing for a work
+type EditionsResponse struct {
+	Size    int       `json:"size"`
+	Entries []Edition `json:"entries"`
+}
+
+// SubjectWork represents a work listed under a subject
+type SubjectWork struct {
+	Key              string `json:"key"`
+	Title            string `json:"title"`
+	CoverID          int    `json:"cover_id,omitempty"`
+	EditionCount     int    `json:"edition_count,omitempty"`
+	FirstPublishYear int    `json:"first_publish_year,omitempty"`
+	Authors          []struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"authors,omitempty"`
+	Subject []string `json:"subject,omitempty"`
+}
+
+// SubjectResponse represents the works filed under a subject
+type SubjectResponse struct {
+	Key       string        `json:"key"`
+	Name      string        `json:"name"`
+	WorkCount int           `json:"work_count"`
+	Works     []SubjectWork `json:"works"`
+}
+
 // Client for interacting with the Open Library API
 type Client struct {
 	httpClient *http.Client
@@ -80,7 +132,7 @@ func NewClient() *Client {
 // SearchBooks searches for books using the Open Library API
 func (c *Client) SearchBooks(ctx context.Context, query string) (*SearchResponse, error) {
 	// Create the URL for the search request
-	endpoint := baseURL + searchEndpoint
+	endpoint := endpoints.Get(endpoints.OpenLibrary).URL() + searchEndpoint
 	params := url.Values{}
 	params.Add("q", query)
 	params.Add("limit", "20")
@@ -120,7 +172,7 @@ func (c *Client) GetBookDetails(ctx context.Context, workKey string) (*WorksResp
 	}
 
 	// Create the URL for the book details request
-	endpoint := baseURL + workKey + ".json"
+	endpoint := endpoints.Get(endpoints.OpenLibrary).URL() + workKey + ".json"
 
 	// Make the request
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
@@ -157,7 +209,7 @@ func (c *Client) GetAuthorDetails(ctx context.Context, authorKey string) (*Autho
 	}
 
 	// Create the URL for the author details request
-	endpoint := baseURL + authorKey + ".json"
+	endpoint := endpoints.Get(endpoints.OpenLibrary).URL() + authorKey + ".json"
 
 	// Make the request
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
@@ -186,12 +238,166 @@ func (c *Client) GetAuthorDetails(ctx context.Context, authorKey string) (*Autho
 	return &authorResp, nil
 }
 
+// GetEditionByISBN looks up an edition by its ISBN-10 or ISBN-13, with or without hyphens
+func (c *Client) GetEditionByISBN(ctx context.Context, isbn string) (*Edition, error) {
+	normalized, err := NormalizeISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the URL for the ISBN request, Open Library redirects this to the edition
+	endpoint := endpoints.Get(endpoints.OpenLibrary).URL() + isbnEndpoint + "/" + normalized + ".json"
+
+	// Make the request
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ISBN request: %w", err)
+	}
+
+	// Send the request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send ISBN request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for errors
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no edition found for ISBN %s", normalized)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ISBN request failed with status: %s", resp.Status)
+	}
+
+	// Parse the response
+	var edition Edition
+	if err := json.NewDecoder(resp.Body).Decode(&edition); err != nil {
+		return nil, fmt.Errorf("failed to decode ISBN response: %w", err)
+	}
+
+	return &edition, nil
+}
+
+// GetEditions lists the editions of a work
+func (c *Client) GetEditions(ctx context.Context, workKey string, limit int) (*EditionsResponse, error) {
+	// Ensure the key starts with /works/
+	if !strings.HasPrefix(workKey, "/works/") {
+		workKey = "/works/" + strings.TrimPrefix(workKey, "/works/")
+	}
+
+	// Create the URL for the editions request
+	endpoint := endpoints.Get(endpoints.OpenLibrary).URL() + workKey + editionsEndpoint
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(limit))
+
+	// Make the request
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create editions request: %w", err)
+	}
+
+	// Send the request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send editions request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("editions request failed with status: %s", resp.Status)
+	}
+
+	// Parse the response
+	var editionsResp EditionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&editionsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode editions response: %w", err)
+	}
+
+	return &editionsResp, nil
+}
+
+// GetSubject lists works filed under a subject, e.g. "science fiction"
+func (c *Client) GetSubject(ctx context.Context, subject string, limit int) (*SubjectResponse, error) {
+	slug := SubjectSlug(subject)
+	if slug == "" {
+		return nil, fmt.Errorf("subject cannot be empty")
+	}
+
+	// Create the URL for the subject request
+	endpoint := endpoints.Get(endpoints.OpenLibrary).URL() + subjectEndpoint + "/" + url.PathEscape(slug) + ".json"
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(limit))
+
+	// Make the request
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subject request: %w", err)
+	}
+
+	// Send the request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send subject request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for errors
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("subject request failed with status: %s", resp.Status)
+	}
+
+	// Parse the response
+	var subjectResp SubjectResponse
+	if err := json.NewDecoder(resp.Body).Decode(&subjectResp); err != nil {
+		return nil, fmt.Errorf("failed to decode subject response: %w", err)
+	}
+
+	return &subjectResp, nil
+}
+
+// SubjectSlug converts a subject name to the form used in /subjects URLs
+func SubjectSlug(subject string) string {
+	return strings.Join(strings.Fields(strings.ToLower(subject)), "_")
+}
+
+// NormalizeISBN strips separators from an ISBN and checks it is a plausible ISBN-10 or ISBN-13
+func NormalizeISBN(isbn string) (string, error) {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(isbn) {
+		switch {
+		case r >= '0' && r <= '9', r == 'X':
+			sb.WriteRune(r)
+		case r == '-' || r == ' ':
+		default:
+			return "", fmt.Errorf("invalid character %q in ISBN", r)
+		}
+	}
+
+	normalized := sb.String()
+	switch len(normalized) {
+	case 10:
+		// Only the check digit of an ISBN-10 may be an X
+		if strings.Contains(normalized[:9], "X") {
+			return "", fmt.Errorf("invalid ISBN-10: %s", isbn)
+		}
+	case 13:
+		if strings.Contains(normalized, "X") {
+			return "", fmt.Errorf("invalid ISBN-13: %s", isbn)
+		}
+	default:
+		return "", fmt.Errorf("ISBN must have 10 or 13 digits: %s", isbn)
+	}
+
+	return normalized, nil
+}
+
 // GetCoverURL returns the URL for a book cover
 func (c *Client) GetCoverURL(coverID int) string {
 	if coverID == 0 {
 		return ""
 	}
-	return fmt.Sprintf(coverURLTemplate, fmt.Sprintf("%d", coverID))
+	return endpoints.Get(endpoints.OpenLibraryCovers).URL() + fmt.Sprintf(coverPathTemplate, coverID)
 }
 
 // ConvertToSessionBook converts an Open Library book to a session Book
@@ -0,0 +1,244 @@
+package ranking
+
+import (
+	"fmt"
+	"interestnaut/internal/session"
+	"sort"
+	"strings"
+)
+
+// Kind is a category of feature the model learns weights for
+type Kind string
+
+const (
+	Genre    Kind = "genre"
+	Creator  Kind = "creator"
+	Decade   Kind = "decade"
+	Platform Kind = "platform"
+	Author   Kind = "author"
+)
+
+const (
+	// DefaultThreshold rejects candidates the user's feedback leans clearly against, while letting
+	// anything merely unfamiliar through
+	DefaultThreshold = -0.25
+	// minFeedback is how many answered suggestions the model needs before it rejects anything
+	minFeedback = 5
+	// priorStrength shrinks weights learned from only a handful of outcomes towards neutral
+	priorStrength = 1.0
+)
+
+// kindWeights sets how much each kind counts towards a score; who made something says more about
+// taste than when it was made or what it runs on
+var kindWeights = map[Kind]float64{
+	Genre:    1,
+	Creator:  1.5,
+	Author:   1.5,
+	Decade:   0.5,
+	Platform: 0.5,
+}
+
+// outcomeSignals is how strongly each outcome pulls a feature's weight up or down
+var outcomeSignals = map[session.Outcome]float64{
+	session.Liked:    1,
+	session.Added:    1,
+	session.Disliked: -1,
+	session.Skipped:  -0.25,
+}
+
+// Features are the traits of a piece of media, by kind
+type Features map[Kind][]string
+
+func (f Features) add(kind Kind, values ...string) {
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			f[kind] = append(f[kind], v)
+		}
+	}
+}
+
+// Candidate is media that might be shown to the user, along with what's known about it beyond its content
+type Candidate[T session.Media] struct {
+	Content  T
+	Genres   []string
+	Creators []string
+	Year     int
+}
+
+// FromSuggestion makes a candidate out of a past suggestion
+func FromSuggestion[T session.Media](s session.Suggestion[T]) Candidate[T] {
+	return Candidate[T]{
+		Content:  s.Content,
+		Genres:   []string{s.PrimaryGenre},
+		Creators: s.Creators,
+		Year:     s.Year,
+	}
+}
+
+// Features extracts the candidate's features, reading creators, authors and platforms from whichever
+// media type it holds
+func (c Candidate[T]) Features() Features {
+	f := Features{}
+	f.add(Genre, c.Genres...)
+
+	switch content := any(c.Content).(type) {
+	case session.Music:
+		f.add(Creator, content.Artist)
+	case session.Movie:
+		f.add(Creator, content.Director, content.Writer)
+	case session.TVShow:
+		f.add(Creator, content.Director, content.Writer)
+	case session.Book:
+		f.add(Author, content.Author)
+	case session.VideoGame:
+		f.add(Creator, content.Developer, content.Publisher)
+		f.add(Platform, content.Platforms...)
+	}
+
+	// Books keep their writers apart from everyone else who makes things
+	if _, isBook := any(c.Content).(session.Book); isBook {
+		f.add(Author, c.Creators...)
+	} else {
+		f.add(Creator, c.Creators...)
+	}
+
+	if c.Year > 0 {
+		f.add(Decade, fmt.Sprintf("%ds", c.Year/10*10))
+	}
+
+	return f
+}
+
+// Model holds weights learned from the outcomes of past suggestions
+type Model struct {
+	signal   map[Kind]map[string]float64
+	count    map[Kind]map[string]int
+	feedback int
+}
+
+// Learn builds a model from a session's suggestions; pending ones are ignored
+func Learn[T session.Media](suggestions map[string]session.Suggestion[T]) *Model {
+	m := &Model{
+		signal: make(map[Kind]map[string]float64),
+		count:  make(map[Kind]map[string]int),
+	}
+
+	for _, s := range suggestions {
+		signal, ok := outcomeSignals[s.UserOutcome]
+		if !ok {
+			continue
+		}
+		m.feedback++
+
+		// Each value counts once per suggestion, however many times it appears
+		for kind, values := range FromSuggestion(s).Features() {
+			if m.signal[kind] == nil {
+				m.signal[kind] = make(map[string]float64)
+				m.count[kind] = make(map[string]int)
+			}
+			seen := make(map[string]bool)
+			for _, v := range values {
+				if seen[v] {
+					continue
+				}
+				seen[v] = true
+				m.signal[kind][v] += signal
+				m.count[kind][v]++
+			}
+		}
+	}
+
+	return m
+}
+
+// Weight returns the learned weight of a feature, between -1 and 1, where 0 means neutral or unseen
+func (m *Model) Weight(kind Kind, value string) float64 {
+	value = strings.ToLower(strings.TrimSpace(value))
+	count := m.count[kind][value]
+	if count == 0 {
+		return 0
+	}
+	return m.signal[kind][value] / (float64(count) + priorStrength)
+}
+
+// Score rates features between -1 and 1, averaging each kind's values and weighting the kinds against
+// each other. Features the model hasn't seen count as neutral.
+func (m *Model) Score(f Features) float64 {
+	var total, weights float64
+	for kind, values := range f {
+		if len(values) == 0 {
+			continue
+		}
+
+		var sum float64
+		for _, v := range values {
+			sum += m.Weight(kind, v)
+		}
+
+		w := kindWeights[kind]
+		total += w * sum / float64(len(values))
+		weights += w
+	}
+
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
+
+// Feedback returns how many answered suggestions the model learned from
+func (m *Model) Feedback() int {
+	return m.feedback
+}
+
+// Scored is a candidate along with its score
+type Scored[T session.Media] struct {
+	Candidate[T]
+	Score float64
+}
+
+// Ranker scores candidates against the user's feedback and rejects the ones it leans against
+type Ranker[T session.Media] struct {
+	model     *Model
+	threshold float64
+}
+
+// NewRanker learns from a session's suggestions, rejecting candidates that score below threshold
+func NewRanker[T session.Media](suggestions map[string]session.Suggestion[T], threshold float64) *Ranker[T] {
+	return &Ranker[T]{
+		model:     Learn(suggestions),
+		threshold: threshold,
+	}
+}
+
+// Score rates a candidate between -1 and 1
+func (r *Ranker[T]) Score(c Candidate[T]) float64 {
+	return r.model.Score(c.Features())
+}
+
+// Accept reports whether a candidate should be shown, along with its score. Nothing is rejected until
+// there's enough feedback to go on.
+func (r *Ranker[T]) Accept(c Candidate[T]) (float64, bool) {
+	score := r.Score(c)
+	if r.model.Feedback() < minFeedback {
+		return score, true
+	}
+	return score, score >= r.threshold
+}
+
+// Rank scores candidates, drops the rejected ones and orders the rest best first, keeping the
+// original order between equal scores
+func (r *Ranker[T]) Rank(candidates []Candidate[T]) []Scored[T] {
+	result := make([]Scored[T], 0, len(candidates))
+	for _, c := range candidates {
+		if score, ok := r.Accept(c); ok {
+			result = append(result, Scored[T]{Candidate: c, Score: score})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	return result
+}
@@ -0,0 +1,185 @@
+package ranking
+
+import (
+	"fmt"
+	"interestnaut/internal/session"
+	"math"
+	"slices"
+	"testing"
+)
+
+// feedback makes n answered movie suggestions by director with the given outcome
+func feedback(suggestions map[string]session.Suggestion[session.Movie], director, genre string, outcome session.Outcome, n int) {
+	for i := 0; i < n; i++ {
+		movie := session.Movie{Title: fmt.Sprintf("%s %s %d", director, outcome, i), Director: director}
+		suggestions[movie.Key()] = session.Suggestion[session.Movie]{
+			Content:      movie,
+			PrimaryGenre: genre,
+			UserOutcome:  outcome,
+		}
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Features
+		want Features
+	}{
+		{
+			name: "movie",
+			f: Candidate[session.Movie]{
+				Content: session.Movie{Director: "Denis Villeneuve", Writer: " Jon Spaihts "},
+				Genres:  []string{"Science Fiction"},
+				Year:    2021,
+			}.Features(),
+			want: Features{Genre: {"science fiction"}, Creator: {"denis villeneuve", "jon spaihts"}, Decade: {"2020s"}},
+		},
+		{
+			name: "book creators are authors",
+			f: Candidate[session.Book]{
+				Content:  session.Book{Author: "Ursula K. Le Guin"},
+				Creators: []string{"Ursula K. Le Guin"},
+			}.Features(),
+			want: Features{Author: {"ursula k. le guin", "ursula k. le guin"}},
+		},
+		{
+			name: "game platforms",
+			f: Candidate[session.VideoGame]{
+				Content: session.VideoGame{Developer: "Supergiant Games", Platforms: []string{"PC", ""}},
+				Year:    1999,
+			}.Features(),
+			want: Features{Creator: {"supergiant games"}, Platform: {"pc"}, Decade: {"1990s"}},
+		},
+		{
+			name: "blank values are dropped",
+			f:    Candidate[session.Music]{Content: session.Music{Artist: " "}, Genres: []string{""}}.Features(),
+			want: Features{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.f) != len(tt.want) {
+				t.Fatalf("Features() = %v, want %v", tt.f, tt.want)
+			}
+			for kind, values := range tt.want {
+				if !slices.Equal(tt.f[kind], values) {
+					t.Errorf("Features()[%s] = %v, want %v", kind, tt.f[kind], values)
+				}
+			}
+		})
+	}
+}
+
+func TestLearn(t *testing.T) {
+	suggestions := make(map[string]session.Suggestion[session.Movie])
+	feedback(suggestions, "Liked Director", "drama", session.Liked, 3)
+	feedback(suggestions, "Disliked Director", "horror", session.Disliked, 1)
+	feedback(suggestions, "Skipped Director", "drama", session.Skipped, 1)
+	feedback(suggestions, "Pending Director", "horror", session.Pending, 4)
+
+	m := Learn(suggestions)
+
+	if got := m.Feedback(); got != 5 {
+		t.Errorf("Feedback() = %d, want 5, leaving out pending suggestions", got)
+	}
+
+	tests := []struct {
+		kind  Kind
+		value string
+		want  float64
+	}{
+		{Creator, "Liked Director", 3.0 / 4},
+		{Creator, "liked director", 3.0 / 4},
+		{Creator, "Disliked Director", -1.0 / 2},
+		{Creator, "Skipped Director", -0.25 / 2},
+		{Creator, "Pending Director", 0},
+		{Creator, "Unseen Director", 0},
+		{Genre, "drama", (3 - 0.25) / 5},
+		{Genre, "horror", -1.0 / 2},
+	}
+	for _, tt := range tests {
+		if got := m.Weight(tt.kind, tt.value); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Weight(%s, %q) = %v, want %v", tt.kind, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAccept(t *testing.T) {
+	disliked := Candidate[session.Movie]{Content: session.Movie{Director: "Disliked Director"}, Genres: []string{"horror"}}
+	liked := Candidate[session.Movie]{Content: session.Movie{Director: "Liked Director"}, Genres: []string{"drama"}}
+	unfamiliar := Candidate[session.Movie]{Content: session.Movie{Director: "New Director"}, Genres: []string{"western"}}
+
+	tests := []struct {
+		name      string
+		dislikes  int
+		likes     int
+		candidate Candidate[session.Movie]
+		want      bool
+	}{
+		{"too little feedback to reject", minFeedback - 1, 0, disliked, true},
+		{"enough feedback to reject", minFeedback, 0, disliked, false},
+		{"liked with enough feedback", minFeedback, 1, liked, true},
+		{"unfamiliar with enough feedback", minFeedback, 1, unfamiliar, true},
+		{"mixed feedback", 1, minFeedback, disliked, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := make(map[string]session.Suggestion[session.Movie])
+			feedback(suggestions, "Disliked Director", "horror", session.Disliked, tt.dislikes)
+			feedback(suggestions, "Liked Director", "drama", session.Liked, tt.likes)
+
+			r := NewRanker(suggestions, DefaultThreshold)
+			if score, got := r.Accept(tt.candidate); got != tt.want {
+				t.Errorf("Accept() = %v with score %.2f, want %v", got, score, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcceptThreshold(t *testing.T) {
+	suggestions := make(map[string]session.Suggestion[session.Movie])
+	feedback(suggestions, "Skipped Director", "drama", session.Skipped, minFeedback)
+	candidate := Candidate[session.Movie]{Content: session.Movie{Director: "Skipped Director"}, Genres: []string{"drama"}}
+
+	// Skipping isn't as strong as disliking, so it shouldn't reject anything at the default threshold
+	tests := []struct {
+		threshold float64
+		want      bool
+	}{
+		{DefaultThreshold, true},
+		{-0.2, false},
+		{0, false},
+	}
+
+	for _, tt := range tests {
+		r := NewRanker(suggestions, tt.threshold)
+		if score, got := r.Accept(candidate); got != tt.want {
+			t.Errorf("Accept() at threshold %.2f = %v with score %.2f, want %v", tt.threshold, got, score, tt.want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	suggestions := make(map[string]session.Suggestion[session.Movie])
+	feedback(suggestions, "Liked Director", "drama", session.Liked, minFeedback)
+	feedback(suggestions, "Disliked Director", "horror", session.Disliked, minFeedback)
+
+	candidates := []Candidate[session.Movie]{
+		{Content: session.Movie{Title: "Unfamiliar A", Director: "New Director"}},
+		{Content: session.Movie{Title: "Disliked", Director: "Disliked Director"}, Genres: []string{"horror"}},
+		{Content: session.Movie{Title: "Liked", Director: "Liked Director"}, Genres: []string{"drama"}},
+		{Content: session.Movie{Title: "Unfamiliar B", Director: "Another Director"}},
+	}
+
+	var got []string
+	for _, s := range NewRanker(suggestions, DefaultThreshold).Rank(candidates) {
+		got = append(got, s.Content.Title)
+	}
+
+	if want := []string{"Liked", "Unfamiliar A", "Unfamiliar B"}; !slices.Equal(got, want) {
+		t.Errorf("Rank() = %v, want %v", got, want)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"interestnaut/internal/creds"
+	"interestnaut/internal/endpoints"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +31,8 @@ var ErrNoCredentials = fmt.Errorf("RAWG API key not available")
 type Client interface {
 	GetGame(ctx context.Context, id int) (*Game, error)
 	SearchGames(ctx context.Context, query string, page, pageSize int) (*GameSearchResponse, error)
+	SearchGamesOnPlatforms(ctx context.Context, query string, platformIDs []int, page, pageSize int) (*GameSearchResponse, error)
+	GetPlatforms(ctx context.Context) ([]PlatformDetails, error)
 	GetGames(ctx context.Context, page, pageSize int) (*GameSearchResponse, error)
 	GetGameDetails(ctx context.Context, id int) (*Game, error)
 	RefreshCredentials() bool
@@ -109,6 +114,13 @@ type GameSearchResponse struct {
 	Results  []Game `json:"results"`
 }
 
+// PlatformsResponse represents the response from the RAWG API platforms endpoint
+type PlatformsResponse struct {
+	Count   int               `json:"count"`
+	Next    string            `json:"next"`
+	Results []PlatformDetails `json:"results"`
+}
+
 // SimpleGame is a simplified version of Game used for frontend displays
 type SimpleGame struct {
 	ID              int      `json:"id"`
@@ -180,6 +192,12 @@ func (c *client) HasValidCredentials() bool {
 
 // SearchGames searches for games matching the query
 func (c *client) SearchGames(ctx context.Context, query string, page, pageSize int) (*GameSearchResponse, error) {
+	return c.SearchGamesOnPlatforms(ctx, query, nil, page, pageSize)
+}
+
+// SearchGamesOnPlatforms searches for games matching the query that are available on at least one
+// of the given platforms; no platforms means no filtering
+func (c *client) SearchGamesOnPlatforms(ctx context.Context, query string, platformIDs []int, page, pageSize int) (*GameSearchResponse, error) {
 	apiKey, err := getAPIKey()
 	if err != nil {
 		return nil, ErrNoCredentials
@@ -193,17 +211,26 @@ func (c *client) SearchGames(ctx context.Context, query string, page, pageSize i
 		page = 1
 	}
 
+	queryArgs := map[string][]string{
+		"key":       {apiKey},
+		"search":    {query},
+		"page":      {fmt.Sprintf("%d", page)},
+		"page_size": {fmt.Sprintf("%d", pageSize)},
+	}
+	if len(platformIDs) > 0 {
+		ids := make([]string, len(platformIDs))
+		for i, id := range platformIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		queryArgs["platforms"] = []string{strings.Join(ids, ",")}
+	}
+
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.RAWG).RequestScheme()),
 		request.WithMethod(request.Get),
-		request.WithHost("api.rawg.io"),
+		request.WithHost(endpoints.Get(endpoints.RAWG).Address()),
 		request.WithPath("api", "games"),
-		request.WithQueryArgs(map[string][]string{
-			"key":       {apiKey},
-			"search":    {query},
-			"page":      {fmt.Sprintf("%d", page)},
-			"page_size": {fmt.Sprintf("%d", pageSize)},
-		}),
+		request.WithQueryArgs(queryArgs),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -234,9 +261,9 @@ func (c *client) GetGames(ctx context.Context, page, pageSize int) (*GameSearchR
 	}
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.RAWG).RequestScheme()),
 		request.WithMethod(request.Get),
-		request.WithHost("api.rawg.io"),
+		request.WithHost(endpoints.Get(endpoints.RAWG).Address()),
 		request.WithPath("api", "games"),
 		request.WithQueryArgs(map[string][]string{
 			"key":       {apiKey},
@@ -270,9 +297,9 @@ func (c *client) GetGameDetails(ctx context.Context, id int) (*Game, error) {
 	}
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.RAWG).RequestScheme()),
 		request.WithMethod(request.Get),
-		request.WithHost("api.rawg.io"),
+		request.WithHost(endpoints.Get(endpoints.RAWG).Address()),
 		request.WithPath("api", "games", fmt.Sprintf("%d", id)),
 		request.WithQueryArgs(map[string][]string{
 			"key": {apiKey},
@@ -290,3 +317,43 @@ func (c *client) GetGameDetails(ctx context.Context, id int) (*Game, error) {
 
 	return &game, nil
 }
+
+// GetPlatforms gets every platform known to RAWG
+func (c *client) GetPlatforms(ctx context.Context) ([]PlatformDetails, error) {
+	apiKey, err := getAPIKey()
+	if err != nil {
+		return nil, ErrNoCredentials
+	}
+
+	var platforms []PlatformDetails
+	for page := 1; ; page++ {
+		req, err := request.NewRequester(
+			request.WithScheme(endpoints.Get(endpoints.RAWG).RequestScheme()),
+			request.WithMethod(request.Get),
+			request.WithHost(endpoints.Get(endpoints.RAWG).Address()),
+			request.WithPath("api", "platforms"),
+			request.WithQueryArgs(map[string][]string{
+				"key":       {apiKey},
+				"page":      {fmt.Sprintf("%d", page)},
+				"page_size": {"40"}, // Max allowed by RAWG API
+			}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		var result PlatformsResponse
+		_, err = req.Make(ctx, &result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get platforms: %w", err)
+		}
+
+		platforms = append(platforms, result.Results...)
+
+		if result.Next == "" || len(result.Results) == 0 {
+			break
+		}
+	}
+
+	return platforms, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,40 +13,62 @@ import (
 	"time"
 )
 
-// Start initializes and starts the HTTP server; this is currently only used to respond
+// ErrNoPortAvailable is returned when every candidate port is already in use
+var ErrNoPortAvailable = errors.New("no loopback port available")
+
+// ListenLoopback binds the first free port from ports on 127.0.0.1 only, so the callback
+// server is never reachable from other machines
+func ListenLoopback(ports []int) (net.Listener, error) {
+	for _, port := range ports {
+		ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err != nil {
+			log.Printf("Port %d unavailable: %v", port, err)
+			continue
+		}
+		return ln, nil
+	}
+
+	return nil, fmt.Errorf("%w: tried %v", ErrNoPortAvailable, ports)
+}
+
+// Start serves on the given listener until stopped; this is currently only used to respond
 // to Spotify's auth code callback to exchange for a token; should be short-lived,
 // and shouldn't run when a refresh token is available in the keychain
-func Start(ctx context.Context, stop <-chan struct{}, handler http.Handler) error {
+func Start(ctx context.Context, stop <-chan struct{}, ln net.Listener, handler http.Handler) error {
 	srv := &http.Server{
-		Addr:    ":8080",
-		Handler: handler,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
 
 	// Listen for system interrupts for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
 
 	// Channel to communicate server errors
 	errChan := make(chan error, 1)
 
 	go func() {
-		log.Println("Server listening on http://localhost:8080")
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			errChan <- fmt.Errorf("ListenAndServe error: %w", err)
+		log.Printf("Server listening on http://%s", ln.Addr())
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errChan <- fmt.Errorf("serve error: %w", err)
 		}
 	}()
 
-	// Wait for stop signal or error
+	// Wait for stop signal, cancellation or error
 	select {
 	case <-stop:
 		log.Println("Received stop signal")
+	case <-ctx.Done():
+		log.Println("Server context cancelled")
 	case <-sigChan:
 		log.Println("Received OS interrupt signal")
 	case err := <-errChan:
 		return err
 	}
 
-	cCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	// The parent context may already be done, so don't derive the shutdown deadline from it
+	cCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(cCtx); err != nil {
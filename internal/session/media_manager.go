@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 const (
@@ -56,10 +57,11 @@ func NewQueuedManager(userID string, dataDir string) (*QueuedManager, error) {
 	qm := &QueuedManager{
 		filePath: filePath,
 		data: Queued{
-			Movies:     []Movie{},
-			Books:      []Book{},
-			TVShows:    []TVShow{},
-			VideoGames: []VideoGame{},
+			Movies:      []Movie{},
+			Books:       []Book{},
+			TVShows:     []TVShow{},
+			VideoGames:  []VideoGame{},
+			GameBacklog: map[string]BacklogEntry{},
 		},
 	}
 
@@ -512,6 +514,7 @@ func (qm *QueuedManager) AddVideoGame(videoGame VideoGame) error {
 	}
 
 	qm.data.VideoGames = append(qm.data.VideoGames, videoGame)
+	qm.data.GameBacklog[videoGame.Key()] = newBacklogEntry(videoGame)
 
 	return qm.save()
 }
@@ -537,10 +540,59 @@ func (qm *QueuedManager) RemoveVideoGame(videoGame VideoGame) error {
 	}
 
 	qm.data.VideoGames = newVideoGames
+	delete(qm.data.GameBacklog, videoGame.Key())
 
 	return qm.save()
 }
 
+// GetGameBacklog returns the backlog entry for every queued video game, in queue order
+func (qm *QueuedManager) GetGameBacklog() []BacklogEntry {
+	qm.mu.RLock()
+	defer qm.mu.RUnlock()
+
+	result := make([]BacklogEntry, 0, len(qm.data.VideoGames))
+	for _, v := range qm.data.VideoGames {
+		entry, ok := qm.data.GameBacklog[v.Key()]
+		if !ok {
+			// Games queued before the backlog existed have no entry yet
+			entry = BacklogEntry{Game: v, Status: Unplayed}
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// SetBacklogEntry replaces the backlog details for a queued video game
+func (qm *QueuedManager) SetBacklogEntry(entry BacklogEntry) error {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+
+	found := false
+	for _, v := range qm.data.VideoGames {
+		if v.Equal(entry.Game) {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("video game not found in queue")
+	}
+
+	qm.data.GameBacklog[entry.Game.Key()] = entry
+
+	return qm.save()
+}
+
+// newBacklogEntry starts a game off as unplayed
+func newBacklogEntry(videoGame VideoGame) BacklogEntry {
+	return BacklogEntry{
+		Game:    videoGame,
+		Status:  Unplayed,
+		AddedAt: time.Now().Unix(),
+	}
+}
+
 // load loads queue from disk
 func (qm *QueuedManager) load() error {
 	// Check if file exists
@@ -562,6 +614,10 @@ func (qm *QueuedManager) load() error {
 		return fmt.Errorf("failed to unmarshal queued items: %w", err)
 	}
 
+	if qm.data.GameBacklog == nil {
+		qm.data.GameBacklog = map[string]BacklogEntry{}
+	}
+
 	log.Printf("Successfully loaded queued items with %d movies, %d books, %d TV shows, %d video games",
 		len(qm.data.Movies), len(qm.data.Books), len(qm.data.TVShows), len(qm.data.VideoGames))
 
@@ -0,0 +1,180 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const ProgressSuffix string = "_progress" + Ext
+
+// WatchProgressManager handles storage and retrieval of the user's progress through TV shows
+type WatchProgressManager struct {
+	data     Progress
+	mu       sync.RWMutex
+	filePath string
+}
+
+// NewWatchProgressManager creates a new progress manager and loads existing data
+func NewWatchProgressManager(userID string, dataDir string) (*WatchProgressManager, error) {
+	filePath := filepath.Join(dataDir, fmt.Sprintf("%s%s", userID, ProgressSuffix))
+
+	pm := &WatchProgressManager{
+		filePath: filePath,
+		data: Progress{
+			TVShows: []TVProgress{},
+		},
+	}
+
+	if err := pm.load(); err != nil {
+		log.Printf("WARNING: Failed to load progress: %v", err)
+	}
+
+	return pm, nil
+}
+
+// GetTVProgress returns the progress for every tracked TV show
+func (pm *WatchProgressManager) GetTVProgress() []TVProgress {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	result := make([]TVProgress, len(pm.data.TVShows))
+	copy(result, pm.data.TVShows)
+	return result
+}
+
+// GetTVShowProgress returns the progress for a single show, if it is being tracked. showID is its TMDB ID,
+// or 0 when it isn't known.
+func (pm *WatchProgressManager) GetTVShowProgress(show TVShow, showID int) (TVProgress, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	for _, p := range pm.data.TVShows {
+		if sameShow(p, TVProgress{Show: show, ShowID: showID}) {
+			return p, true
+		}
+	}
+
+	return TVProgress{}, false
+}
+
+// SetTVProgress adds or replaces the progress for a show
+func (pm *WatchProgressManager) SetTVProgress(progress TVProgress) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	progress.UpdatedAt = time.Now().Unix()
+
+	for i, p := range pm.data.TVShows {
+		if sameShow(p, progress) {
+			pm.data.TVShows[i] = progress
+			return pm.save()
+		}
+	}
+
+	pm.data.TVShows = append(pm.data.TVShows, progress)
+
+	return pm.save()
+}
+
+// RemoveTVProgress stops tracking progress for a show. showID is its TMDB ID, or 0 when it isn't known.
+func (pm *WatchProgressManager) RemoveTVProgress(show TVShow, showID int) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	found := false
+	newProgress := make([]TVProgress, 0, len(pm.data.TVShows))
+
+	for _, p := range pm.data.TVShows {
+		if !sameShow(p, TVProgress{Show: show, ShowID: showID}) {
+			newProgress = append(newProgress, p)
+		} else {
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("TV show not found in progress")
+	}
+
+	pm.data.TVShows = newProgress
+
+	return pm.save()
+}
+
+// GetNotifiedEpisodes returns the episodes the user's been alerted to, keyed by TMDB ID, with their air dates
+func (pm *WatchProgressManager) GetNotifiedEpisodes() map[int]string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	episodes := make(map[int]string, len(pm.data.NotifiedEpisodes))
+	maps.Copy(episodes, pm.data.NotifiedEpisodes)
+	return episodes
+}
+
+// SetNotifiedEpisodes replaces the episodes the user's been alerted to, so alerts aren't repeated after a restart
+func (pm *WatchProgressManager) SetNotifiedEpisodes(episodes map[int]string) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.data.NotifiedEpisodes = maps.Clone(episodes)
+
+	return pm.save()
+}
+
+// sameShow prefers the TMDB ID when both sides have one, since titles alone can collide
+func sameShow(a, b TVProgress) bool {
+	if a.ShowID != 0 && b.ShowID != 0 {
+		return a.ShowID == b.ShowID
+	}
+	return a.Show.Equal(b.Show)
+}
+
+// load loads progress from disk
+func (pm *WatchProgressManager) load() error {
+	// Check if file exists
+	_, err := os.Stat(pm.filePath)
+	if os.IsNotExist(err) {
+		// File doesn't exist, initialize with empty data
+		log.Printf("No progress file exists at %s, initializing with empty data", pm.filePath)
+		return pm.save() // Create the file
+	}
+
+	// Read the file
+	data, err := os.ReadFile(pm.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read progress file: %w", err)
+	}
+
+	// Unmarshal the data
+	if err := json.Unmarshal(data, &pm.data); err != nil {
+		return fmt.Errorf("failed to unmarshal progress: %w", err)
+	}
+
+	log.Printf("Successfully loaded progress with %d TV shows", len(pm.data.TVShows))
+
+	return nil
+}
+
+// save saves progress to disk
+func (pm *WatchProgressManager) save() error {
+	// Marshal the data
+	data, err := json.MarshalIndent(pm.data, "", "  ") // Pretty print for easier debugging
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress: %w", err)
+	}
+
+	// Write the file
+	if err := os.WriteFile(pm.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+
+	log.Printf("Successfully saved progress to %s", pm.filePath)
+
+	return nil
+}
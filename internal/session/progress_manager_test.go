@@ -0,0 +1,89 @@
+package session
+
+import "testing"
+
+func TestWatchProgressManagerMatchesByShowID(t *testing.T) {
+	office := TVShow{Title: "The Office", Writer: "Ricky Gervais"}
+	officeUS := TVShow{Title: "The Office", Writer: "Greg Daniels"}
+	renamed := TVShow{Title: "The Office (US)", Writer: "Greg Daniels"}
+	untracked := TVShow{Title: "Untracked"}
+
+	tests := []struct {
+		name      string
+		show      TVShow
+		showID    int
+		wantFound bool
+		wantID    int
+	}{
+		{"by ID", officeUS, 2316, true, 2316},
+		{"by ID after a rename", renamed, 2316, true, 2316},
+		{"by ID when the details differ", office, 2316, true, 2316},
+		{"different ID with the same details", officeUS, 9999, false, 0},
+		{"by details without an ID", office, 0, true, 2996},
+		{"untracked", untracked, 0, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm, err := NewWatchProgressManager("test", t.TempDir())
+			if err != nil {
+				t.Fatalf("NewWatchProgressManager() failed: %v", err)
+			}
+			for _, p := range []TVProgress{
+				{Show: office, ShowID: 2996, Season: 2, Episode: 6},
+				{Show: officeUS, ShowID: 2316, Season: 4, Episode: 1},
+			} {
+				if err := pm.SetTVProgress(p); err != nil {
+					t.Fatalf("SetTVProgress() failed: %v", err)
+				}
+			}
+
+			got, found := pm.GetTVShowProgress(tt.show, tt.showID)
+			if found != tt.wantFound || got.ShowID != tt.wantID {
+				t.Errorf("GetTVShowProgress() = %d, %v, want %d, %v", got.ShowID, found, tt.wantID, tt.wantFound)
+			}
+
+			err = pm.RemoveTVProgress(tt.show, tt.showID)
+			if (err == nil) != tt.wantFound {
+				t.Fatalf("RemoveTVProgress() error = %v, want found %v", err, tt.wantFound)
+			}
+			remaining := pm.GetTVProgress()
+			for _, p := range remaining {
+				if tt.wantFound && p.ShowID == tt.wantID {
+					t.Errorf("RemoveTVProgress() left show %d behind", tt.wantID)
+				}
+			}
+			wantRemaining := 2
+			if tt.wantFound {
+				wantRemaining = 1
+			}
+			if len(remaining) != wantRemaining {
+				t.Errorf("RemoveTVProgress() left %d shows, want %d", len(remaining), wantRemaining)
+			}
+		})
+	}
+}
+
+func TestWatchProgressManagerKeepsNotifiedEpisodes(t *testing.T) {
+	dir := t.TempDir()
+
+	pm, err := NewWatchProgressManager("test", dir)
+	if err != nil {
+		t.Fatalf("NewWatchProgressManager() failed: %v", err)
+	}
+	if got := pm.GetNotifiedEpisodes(); got == nil || len(got) != 0 {
+		t.Fatalf("GetNotifiedEpisodes() = %v, want an empty map", got)
+	}
+	if err := pm.SetNotifiedEpisodes(map[int]string{42: "2026-10-20"}); err != nil {
+		t.Fatalf("SetNotifiedEpisodes() failed: %v", err)
+	}
+
+	// As after a restart
+	reloaded, err := NewWatchProgressManager("test", dir)
+	if err != nil {
+		t.Fatalf("NewWatchProgressManager() failed: %v", err)
+	}
+	if got := reloaded.GetNotifiedEpisodes(); len(got) != 1 || got[42] != "2026-10-20" {
+		t.Errorf("GetNotifiedEpisodes() after reloading = %v, want episode 42", got)
+	}
+}
@@ -5,19 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
+	"time"
 )
 
-type subject string
+// MediaType identifies one of the kinds of media, each with its own session
+type MediaType string
 
 const (
-	music     subject = "music"
-	movie     subject = "movie"
-	book      subject = "book"
-	tv        subject = "tv"
-	videoGame subject = "video_game"
+	MusicMedia     MediaType = "music"
+	MovieMedia     MediaType = "movie"
+	BookMedia      MediaType = "book"
+	TVMedia        MediaType = "tv"
+	VideoGameMedia MediaType = "video_game"
 )
 
 type Manager[T Media] interface {
@@ -25,6 +29,9 @@ type Manager[T Media] interface {
 	GetSession(ctx context.Context, key Key) (*Session[T], error)
 	AddSuggestion(context.Context, *Session[T], Suggestion[T]) error
 	UpdateSuggestionOutcome(ctx context.Context, session *Session[T], suggestionKey string, outcome Outcome) error
+	AddRefinement(context.Context, *Session[T], Refinement) error
+	SetProfile(ctx context.Context, session *Session[T], previous *TasteProfile, profile TasteProfile) error
+	Snapshot(*Session[T]) Session[T]
 	Key() Key
 }
 
@@ -56,6 +63,17 @@ type QueueManager interface {
 	RemoveBook(Book) error
 	RemoveTVShow(TVShow) error
 	RemoveVideoGame(VideoGame) error
+	GetGameBacklog() []BacklogEntry
+	SetBacklogEntry(BacklogEntry) error
+}
+
+type ProgressManager interface {
+	GetTVProgress() []TVProgress
+	GetTVShowProgress(show TVShow, showID int) (TVProgress, bool)
+	SetTVProgress(TVProgress) error
+	RemoveTVProgress(show TVShow, showID int) error
+	GetNotifiedEpisodes() map[int]string
+	SetNotifiedEpisodes(map[int]string) error
 }
 
 type CentralManager interface {
@@ -67,6 +85,7 @@ type CentralManager interface {
 	Settings() Settings
 	Favorites() FavoriteManager
 	Queue() QueueManager
+	Progress() ProgressManager
 }
 
 type manager[T Media] struct {
@@ -85,17 +104,18 @@ type centralManager struct {
 	settings         Settings
 	favoriteManager  FavoriteManager
 	queueManager     QueueManager
+	progressManager  ProgressManager
 	dataDir          string
 	userID           string
 }
 
-func newManager[T Media](ctx context.Context, userID, dataDir string, subject subject) Manager[T] {
+func newManager[T Media](ctx context.Context, userID, dataDir string, mediaType MediaType) Manager[T] {
 	m := &manager[T]{
 		sessions: make(map[Key]*Session[T]),
 		dataDir:  dataDir,
 	}
 
-	key := Key(fmt.Sprintf("%s_%s", userID, subject))
+	key := Key(fmt.Sprintf("%s_%s", userID, mediaType))
 	m.key = key
 
 	if err := m.loadSession(ctx, key); err != nil {
@@ -105,14 +125,23 @@ func newManager[T Media](ctx context.Context, userID, dataDir string, subject su
 	return m
 }
 
-func NewCentralManager(ctx context.Context, userID string) (CentralManager, error) {
+// DataDir returns the directory user data is stored in, creating it if it doesn't exist
+func DataDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user home directory: %w", err)
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
 	}
 	dataDir := filepath.Join(homeDir, ".interestnaut", "sessions")
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create data directory: %w", err)
+		return "", fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return dataDir, nil
+}
+
+func NewCentralManager(ctx context.Context, userID string) (CentralManager, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize favorites and queued managers first
@@ -136,22 +165,35 @@ func NewCentralManager(ctx context.Context, userID string) (CentralManager, erro
 		queuedManager = &QueuedManager{
 			filePath: filepath.Join(dataDir, fmt.Sprintf("%s%s", userID, QueuedSuffix)),
 			data: Queued{
-				Movies:     []Movie{},
-				Books:      []Book{},
-				TVShows:    []TVShow{},
-				VideoGames: []VideoGame{},
+				Movies:      []Movie{},
+				Books:       []Book{},
+				TVShows:     []TVShow{},
+				VideoGames:  []VideoGame{},
+				GameBacklog: map[string]BacklogEntry{},
+			},
+		}
+	}
+
+	progressManager, err := NewWatchProgressManager(userID, dataDir)
+	if err != nil {
+		log.Printf("WARNING: Failed to initialize progress manager: %v", err)
+		progressManager = &WatchProgressManager{
+			filePath: filepath.Join(dataDir, fmt.Sprintf("%s%s", userID, ProgressSuffix)),
+			data: Progress{
+				TVShows: []TVProgress{},
 			},
 		}
 	}
 
 	cm := &centralManager{
-		musicManager:     newManager[Music](ctx, userID, dataDir, music),
-		movieManager:     newManager[Movie](ctx, userID, dataDir, movie),
-		tvShowManager:    newManager[TVShow](ctx, userID, dataDir, tv),
-		bookManager:      newManager[Book](ctx, userID, dataDir, book),
-		videoGameManager: newManager[VideoGame](ctx, userID, dataDir, videoGame),
+		musicManager:     newManager[Music](ctx, userID, dataDir, MusicMedia),
+		movieManager:     newManager[Movie](ctx, userID, dataDir, MovieMedia),
+		tvShowManager:    newManager[TVShow](ctx, userID, dataDir, TVMedia),
+		bookManager:      newManager[Book](ctx, userID, dataDir, BookMedia),
+		videoGameManager: newManager[VideoGame](ctx, userID, dataDir, VideoGameMedia),
 		favoriteManager:  favoritesManager,
 		queueManager:     queuedManager,
+		progressManager:  progressManager,
 		dataDir:          dataDir,
 		userID:           userID,
 	}
@@ -193,10 +235,13 @@ func (m *manager[T]) GetOrCreateSession(ctx context.Context, key Key, directive,
 	session, exists := m.sessions[key]
 	if !exists {
 		log.Printf("No session found in memory for key %s, trying to load from disk", key)
-		// Try to load from disk first
-		if err := m.loadSession(ctx, key); err == nil {
-			session = m.sessions[key]
-			log.Printf("Successfully loaded session from disk for key %s", key)
+		// Try to load from disk first; m.mu is already held, so read it without storing through loadSession
+		if loaded, err := m.readSession(ctx, key); err == nil {
+			if loaded != nil {
+				session = loaded
+				m.sessions[key] = loaded
+				log.Printf("Successfully loaded session from disk for key %s", key)
+			}
 		} else {
 			log.Printf("Failed to load session from disk for key %s: %v", key, err)
 		}
@@ -241,6 +286,10 @@ func (m *manager[T]) AddSuggestion(
 		return fmt.Errorf("duplicate suggestion")
 	}
 
+	if suggestion.SuggestedAt == 0 {
+		suggestion.SuggestedAt = time.Now().UnixMilli()
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -249,6 +298,46 @@ func (m *manager[T]) AddSuggestion(
 	return m.saveSession(ctx, session)
 }
 
+// AddRefinement records a refinement in the session's thread
+func (m *manager[T]) AddRefinement(ctx context.Context, session *Session[T], refinement Refinement) error {
+	if refinement.CreatedAt == 0 {
+		refinement.CreatedAt = time.Now().Unix()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.Refinements = append(session.Refinements, refinement)
+
+	return m.saveSession(ctx, session)
+}
+
+// SetProfile replaces the session's taste profile, as long as it's still the previous one it was based on.
+// Otherwise it's been regenerated or edited in the meantime, and that's kept instead.
+func (m *manager[T]) SetProfile(ctx context.Context, session *Session[T], previous *TasteProfile, profile TasteProfile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session.Profile != previous {
+		return fmt.Errorf("taste profile changed while it was being updated")
+	}
+	session.Profile = &profile
+
+	return m.saveSession(ctx, session)
+}
+
+// Snapshot returns a copy of the session that can be read while the session itself is being changed
+func (m *manager[T]) Snapshot(session *Session[T]) Session[T] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot := *session
+	snapshot.Suggestions = maps.Clone(session.Suggestions)
+	snapshot.UserConstraints = slices.Clone(session.UserConstraints)
+	snapshot.Refinements = slices.Clone(session.Refinements)
+	return snapshot
+}
+
 func (cm *centralManager) Favorites() FavoriteManager {
 	return cm.favoriteManager
 }
@@ -257,6 +346,10 @@ func (cm *centralManager) Queue() QueueManager {
 	return cm.queueManager
 }
 
+func (cm *centralManager) Progress() ProgressManager {
+	return cm.progressManager
+}
+
 func (cm *centralManager) Settings() Settings {
 	return cm.settings
 }
@@ -295,6 +388,7 @@ func (m *manager[T]) UpdateSuggestionOutcome(
 
 	// Update the suggestion
 	suggestion.UserOutcome = outcome
+	suggestion.RespondedAt = time.Now().UnixMilli()
 	// Update the map with the modified suggestion
 	session.Suggestions[suggestionKey] = suggestion
 
@@ -327,7 +421,21 @@ func composeSession[T Media](
 	}
 }
 
-func (m *manager[T]) loadSession(_ context.Context, key Key) error {
+func (m *manager[T]) loadSession(ctx context.Context, key Key) error {
+	session, err := m.readSession(ctx, key)
+	if err != nil || session == nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.sessions[key] = session
+	m.mu.Unlock()
+
+	return nil
+}
+
+// readSession reads a session from disk without storing it, returning nil if there isn't one
+func (m *manager[T]) readSession(_ context.Context, key Key) (*Session[T], error) {
 	filePath := filepath.Join(m.dataDir, fmt.Sprintf("%s%s", key, Ext))
 	log.Printf("Attempting to load session from file: %s", filePath)
 
@@ -335,25 +443,21 @@ func (m *manager[T]) loadSession(_ context.Context, key Key) error {
 	if err != nil {
 		if os.IsNotExist(err) {
 			log.Printf("No session file exists for key %s", key)
-			return nil // Not an error if file doesn't exist
+			return nil, nil // Not an error if file doesn't exist
 		}
 		log.Printf("Failed to read session file for key %s: %v", key, err)
-		return fmt.Errorf("failed to read session file: %w", err)
+		return nil, fmt.Errorf("failed to read session file: %w", err)
 	}
 
 	var session Session[T]
 	session.Key = key
 	if err := json.Unmarshal(data, &session); err != nil {
 		log.Printf("Failed to unmarshal session for key %s: %v", key, err)
-		return fmt.Errorf("failed to unmarshal session: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
 	}
 
-	m.mu.Lock()
-	m.sessions[key] = &session
-	m.mu.Unlock()
-
 	log.Printf("Successfully loaded session for key %s with %d suggestions", key, len(session.Suggestions))
-	return nil
+	return &session, nil
 }
 
 func (m *manager[T]) saveSession(_ context.Context, session *Session[T]) error {
@@ -7,6 +7,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 )
 
 // Settings defines the interface for application settings
@@ -19,15 +21,28 @@ type Settings interface {
 	SetLLMProvider(context.Context, string) error
 	GetGeminiModel() string
 	SetGeminiModel(context.Context, string) error
+	GetOwnedPlatforms() []GamePlatform
+	SetOwnedPlatforms(context.Context, []GamePlatform) error
+	GetDiscoveryPlaylist() DiscoveryPlaylist
+	SetDiscoveryPlaylist(context.Context, DiscoveryPlaylist) error
+	GetMusicUnit() MusicUnit
+	SetMusicUnit(context.Context, MusicUnit) error
+	GetNovelty(MediaType) Novelty
+	SetNovelty(context.Context, MediaType, Novelty) error
 }
 
 // settings implements the Settings interface
 type settings struct {
-	ContinuousPlayback bool   `json:"continuous_playback"`
-	ChatGPTModel       string `json:"chatgpt_model"`
-	LLMProvider        string `json:"llm_provider"`
-	GeminiModel        string `json:"gemini_model"`
-	path               string // This field is not serialized
+	ContinuousPlayback bool                  `json:"continuous_playback"`
+	ChatGPTModel       string                `json:"chatgpt_model"`
+	LLMProvider        string                `json:"llm_provider"`
+	GeminiModel        string                `json:"gemini_model"`
+	OwnedPlatforms     []GamePlatform        `json:"owned_platforms"`
+	DiscoveryPlaylist  DiscoveryPlaylist     `json:"discovery_playlist"`
+	MusicUnit          MusicUnit             `json:"music_unit"`
+	Novelty            map[MediaType]Novelty `json:"novelty,omitempty"` // Media types left out use DefaultNovelty
+	path               string                // This field is not serialized
+	mu                 sync.RWMutex          // Settings are read from background fetches while they're changed
 }
 
 // Default settings values
@@ -35,6 +50,10 @@ const (
 	DefaultChatGPTModel = "gpt-4o"
 	DefaultLLMProvider  = "openai"
 	DefaultGeminiModel  = "gemini-1.5-pro"
+	DefaultMusicUnit    = TrackUnit
+	DefaultNovelty      = Balanced
+
+	DefaultDiscoveryPlaylistName = "Interestnaut Discoveries"
 )
 
 // NewSettings creates a new settings instance or loads it from disk
@@ -51,7 +70,12 @@ func NewSettings(userID, dataDir string) (Settings, error) {
 				ChatGPTModel:       DefaultChatGPTModel,
 				LLMProvider:        DefaultLLMProvider,
 				GeminiModel:        DefaultGeminiModel,
-				path:               filePath,
+				DiscoveryPlaylist: DiscoveryPlaylist{
+					Enabled: true,
+					Name:    DefaultDiscoveryPlaylistName,
+				},
+				MusicUnit: DefaultMusicUnit,
+				path:      filePath,
 			}
 			if sErr := defaultSettings.saveSettings(); sErr != nil {
 				return nil, fmt.Errorf("failed to save default settings: %w", sErr)
@@ -82,6 +106,13 @@ func NewSettings(userID, dataDir string) (Settings, error) {
 	if s.GeminiModel == "" {
 		s.GeminiModel = DefaultGeminiModel
 	}
+	if s.DiscoveryPlaylist.Name == "" {
+		// Settings from before the discovery playlist existed
+		s.DiscoveryPlaylist = DiscoveryPlaylist{
+			Enabled: true,
+			Name:    DefaultDiscoveryPlaylistName,
+		}
+	}
 
 	// Save if we had to set defaults
 	if s.ChatGPTModel == DefaultChatGPTModel || s.LLMProvider == DefaultLLMProvider || s.GeminiModel == DefaultGeminiModel {
@@ -98,16 +129,25 @@ func NewSettings(userID, dataDir string) (Settings, error) {
 
 // ContinuousPlayback settings
 func (s *settings) SetContinuousPlayback(_ context.Context, continuous bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.ContinuousPlayback = continuous
 	return s.saveSettings()
 }
 
 func (s *settings) GetContinuousPlayback() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return s.ContinuousPlayback
 }
 
 // ChatGPT model settings
 func (s *settings) GetChatGPTModel() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if s.ChatGPTModel == "" {
 		return DefaultChatGPTModel
 	}
@@ -115,12 +155,18 @@ func (s *settings) GetChatGPTModel() string {
 }
 
 func (s *settings) SetChatGPTModel(_ context.Context, model string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.ChatGPTModel = model
 	return s.saveSettings()
 }
 
 // LLM provider settings
 func (s *settings) GetLLMProvider() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if s.LLMProvider == "" {
 		return DefaultLLMProvider
 	}
@@ -128,12 +174,18 @@ func (s *settings) GetLLMProvider() string {
 }
 
 func (s *settings) SetLLMProvider(_ context.Context, provider string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.LLMProvider = provider
 	return s.saveSettings()
 }
 
 // Gemini model settings
 func (s *settings) GetGeminiModel() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	if s.GeminiModel == "" {
 		return DefaultGeminiModel
 	}
@@ -141,11 +193,89 @@ func (s *settings) GetGeminiModel() string {
 }
 
 func (s *settings) SetGeminiModel(_ context.Context, model string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.GeminiModel = model
 	return s.saveSettings()
 }
 
-// saveSettings persists the settings to disk
+// Owned gaming platform settings
+func (s *settings) GetOwnedPlatforms() []GamePlatform {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]GamePlatform, len(s.OwnedPlatforms))
+	copy(result, s.OwnedPlatforms)
+	return result
+}
+
+func (s *settings) SetOwnedPlatforms(_ context.Context, platforms []GamePlatform) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.OwnedPlatforms = slices.Clone(platforms)
+	return s.saveSettings()
+}
+
+// Discovery playlist settings
+func (s *settings) GetDiscoveryPlaylist() DiscoveryPlaylist {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.DiscoveryPlaylist
+}
+
+func (s *settings) SetDiscoveryPlaylist(_ context.Context, playlist DiscoveryPlaylist) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.DiscoveryPlaylist = playlist
+	return s.saveSettings()
+}
+
+// Music recommendation unit settings
+func (s *settings) GetMusicUnit() MusicUnit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.MusicUnit == "" {
+		return DefaultMusicUnit
+	}
+	return s.MusicUnit
+}
+
+func (s *settings) SetMusicUnit(_ context.Context, unit MusicUnit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.MusicUnit = unit
+	return s.saveSettings()
+}
+
+// Novelty settings, per media type
+func (s *settings) GetNovelty(mediaType MediaType) Novelty {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if novelty, ok := s.Novelty[mediaType]; ok && novelty != "" {
+		return novelty
+	}
+	return DefaultNovelty
+}
+
+func (s *settings) SetNovelty(_ context.Context, mediaType MediaType, novelty Novelty) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Novelty == nil {
+		s.Novelty = make(map[MediaType]Novelty)
+	}
+	s.Novelty[mediaType] = novelty
+	return s.saveSettings()
+}
+
+// saveSettings persists the settings to disk; callers hold mu, apart from NewSettings
 func (s *settings) saveSettings() error {
 	data, err := json.Marshal(s)
 	if err != nil {
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestSettingsConcurrentAccess(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSettings("test", dir)
+	if err != nil {
+		t.Fatalf("NewSettings() failed: %v", err)
+	}
+
+	// Run with -race: background fetches read settings while the user changes them
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for _, novelty := range []Novelty{Familiar, Adventurous, Balanced} {
+				if err := s.SetNovelty(context.Background(), MovieMedia, novelty); err != nil {
+					t.Errorf("SetNovelty() failed: %v", err)
+				}
+				if err := s.SetOwnedPlatforms(context.Background(), []GamePlatform{{ID: 4, Name: "PC"}}); err != nil {
+					t.Errorf("SetOwnedPlatforms() failed: %v", err)
+				}
+				if err := s.SetMusicUnit(context.Background(), AlbumUnit); err != nil {
+					t.Errorf("SetMusicUnit() failed: %v", err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				s.GetNovelty(MovieMedia)
+				s.GetNovelty(BookMedia)
+				s.GetOwnedPlatforms()
+				s.GetMusicUnit()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := s.GetNovelty(MovieMedia); got != Balanced {
+		t.Errorf("GetNovelty(MovieMedia) = %s, want %s", got, Balanced)
+	}
+	if got := s.GetNovelty(BookMedia); got != DefaultNovelty {
+		t.Errorf("GetNovelty(BookMedia) = %s, want %s", got, DefaultNovelty)
+	}
+
+	reloaded, err := NewSettings("test", dir)
+	if err != nil {
+		t.Fatalf("NewSettings() failed: %v", err)
+	}
+	if got := reloaded.GetNovelty(MovieMedia); got != Balanced {
+		t.Errorf("GetNovelty(MovieMedia) after reloading = %s, want %s", got, Balanced)
+	}
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 const DefaultUserID string = "default_user"
@@ -57,6 +58,31 @@ type VideoGame struct {
 	CoverPath string   `json:"cover_path"`
 }
 
+// GamePlatform is a gaming platform the user owns, identified by its RAWG ID
+type GamePlatform struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// DiscoveryPlaylist configures the Spotify playlist that accepted music suggestions are added to;
+// MaxTracks of 0 means the playlist is never trimmed
+type DiscoveryPlaylist struct {
+	Enabled    bool   `json:"enabled"`
+	Name       string `json:"name"`
+	PlaylistID string `json:"playlist_id"` // Spotify ID, found or created on first use
+	MaxTracks  int    `json:"max_tracks"`
+}
+
+// MusicUnit is the level music is recommended at
+type MusicUnit string
+
+const (
+	TrackUnit  MusicUnit = "track"
+	AlbumUnit  MusicUnit = "album"
+	ArtistUnit MusicUnit = "artist"
+)
+
 type EquatableMedia interface {
 	Equal(other any) bool
 	Key() string
@@ -73,7 +99,15 @@ func (m Music) Equal(other any) bool {
 		m.Album == o.Album
 }
 
+// Key depends on the unit the music was suggested at: albums have no title, and artists have
+// neither a title nor an album
 func (m Music) Key() string {
+	switch {
+	case m.Title == "" && m.Album == "":
+		return sanitizeKey(m.Artist)
+	case m.Title == "":
+		return sanitizeKey(fmt.Sprintf("%s_%s", m.Artist, m.Album))
+	}
 	return sanitizeKey(fmt.Sprintf("%s_%s_%s", m.Title, m.Artist, m.Album))
 }
 
@@ -126,8 +160,92 @@ type Suggestion[T Media] struct {
 	PrimaryGenre string  `json:"primary_genre"`
 	Reasoning    string  `json:"reasoning"`
 	UserOutcome  Outcome `json:"user_outcome"`
-	RespondedAt  int64   `json:"responded_at"`
+	RespondedAt  int64   `json:"responded_at"` // Unix milliseconds, 0 for outcomes given before it was recorded
 	Content      T       `json:"content"`
+	// Year and Creators are kept alongside Content, rather than in it, so they don't change its key
+	Year        int             `json:"year,omitempty"`
+	Creators    []string        `json:"creators,omitempty"`
+	SuggestedAt int64           `json:"suggested_at,omitempty"` // Unix milliseconds, 0 for suggestions made before it was recorded
+	Context     *RequestContext `json:"context,omitempty"`      // The circumstances it was asked for in, if any were given
+}
+
+// Novelty is how far from the familiar suggestions should stray
+type Novelty string
+
+const (
+	Familiar    Novelty = "familiar"    // Well-known picks close to what the user already likes
+	Balanced    Novelty = "balanced"    // No preference either way
+	Adventurous Novelty = "adventurous" // Deep cuts from new creators and genres
+)
+
+// Company is who the user will be enjoying a suggestion with
+type Company string
+
+const (
+	Solo    Company = "solo"
+	Partner Company = "partner"
+	Kids    Company = "kids"
+)
+
+// EnergyLevel is how much the user feels up for
+type EnergyLevel string
+
+const (
+	LowEnergy    EnergyLevel = "low"
+	MediumEnergy EnergyLevel = "medium"
+	HighEnergy   EnergyLevel = "high"
+)
+
+// RequestContext describes the circumstances a suggestion is asked for in; every field is optional
+type RequestContext struct {
+	Mood          string      `json:"mood,omitempty"`
+	TimeAvailable int         `json:"time_available,omitempty"` // Minutes, 0 when not given
+	Company       Company     `json:"company,omitempty"`
+	Energy        EnergyLevel `json:"energy,omitempty"`
+	Occasion      string      `json:"occasion,omitempty"`
+}
+
+// IsEmpty reports whether no context was given
+func (c RequestContext) IsEmpty() bool {
+	return c == RequestContext{}
+}
+
+// String describes the context for a prompt, e.g. "mood: cozy; time available: 90 minutes; company: kids"
+func (c RequestContext) String() string {
+	var parts []string
+	if c.Mood != "" {
+		parts = append(parts, "mood: "+c.Mood)
+	}
+	if c.TimeAvailable > 0 {
+		parts = append(parts, fmt.Sprintf("time available: %d minutes", c.TimeAvailable))
+	}
+	if c.Company != "" {
+		parts = append(parts, "company: "+string(c.Company))
+	}
+	if c.Energy != "" {
+		parts = append(parts, "energy: "+string(c.Energy))
+	}
+	if c.Occasion != "" {
+		parts = append(parts, "occasion: "+c.Occasion)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Refinement records the user asking for something like a suggestion but different, e.g. "like this, but darker"
+type Refinement struct {
+	From        string `json:"from"` // What was being refined, e.g. "'Heat' by Michael Mann"
+	Instruction string `json:"instruction"`
+	Result      string `json:"result"` // What was suggested in response
+	CreatedAt   int64  `json:"created_at"`
+}
+
+// TasteProfile is the LLM's summary of what the user likes in one kind of media, built from the baseline and
+// their feedback. It's sent in place of the baseline and the suggestions it covers, and the user can edit it.
+type TasteProfile struct {
+	Text        string `json:"text"`
+	GeneratedAt int64  `json:"generated_at"`     // Unix milliseconds; suggestions made before then are covered by it
+	Answered    int    `json:"answered"`         // How many suggestions had an outcome when it was generated
+	Edited      bool   `json:"edited,omitempty"` // Whether the user has changed it since
 }
 
 type PrimeDirective struct {
@@ -139,6 +257,8 @@ type Content[T Media] struct {
 	PrimeDirective  `json:"prime_directive"`
 	Suggestions     map[string]Suggestion[T] `json:"suggestions"`
 	UserConstraints []string                 `json:"user_constraints"` // Miscellaneous user-defined constraints that can help temper suggestions
+	Refinements     []Refinement             `json:"refinements,omitempty"`
+	Profile         *TasteProfile            `json:"profile,omitempty"`
 }
 
 func (c Content[T]) ToString() (string, error) {
@@ -155,6 +275,17 @@ type Session[T Media] struct {
 	Content[T] `json:"content"`
 }
 
+// Latest returns the most recently made suggestion, ignoring any made before suggestion times were recorded
+func (s *Session[T]) Latest() (Suggestion[T], bool) {
+	var latest Suggestion[T]
+	for _, suggestion := range s.Suggestions {
+		if suggestion.SuggestedAt > latest.SuggestedAt {
+			latest = suggestion
+		}
+	}
+	return latest, latest.SuggestedAt > 0
+}
+
 // Favorites stores user favorites for all media types sans Spotify governed music
 type Favorites struct {
 	Movies     []Movie     `json:"movies"`
@@ -165,10 +296,60 @@ type Favorites struct {
 
 // Queued stores user queued items for all media types (except music)
 type Queued struct {
-	Movies     []Movie     `json:"movies"`
-	Books      []Book      `json:"books"`
-	TVShows    []TVShow    `json:"tv_shows"`
-	VideoGames []VideoGame `json:"video_games"`
+	Movies      []Movie                 `json:"movies"`
+	Books       []Book                  `json:"books"`
+	TVShows     []TVShow                `json:"tv_shows"`
+	VideoGames  []VideoGame             `json:"video_games"`
+	GameBacklog map[string]BacklogEntry `json:"game_backlog"` // Keyed by VideoGame.Key()
+}
+
+// BacklogStatus describes where the user is with a queued game
+type BacklogStatus string
+
+const (
+	Unplayed  BacklogStatus = "unplayed"
+	Playing   BacklogStatus = "playing"
+	Beaten    BacklogStatus = "beaten"
+	Abandoned BacklogStatus = "abandoned"
+)
+
+// BacklogEntry carries the backlog details for a queued game; Playtime is RAWG's
+// estimate in hours (0 when unknown) and higher Priority means play sooner
+type BacklogEntry struct {
+	Game       VideoGame     `json:"game"`
+	Playtime   int           `json:"playtime"`
+	Priority   int           `json:"priority"`
+	Status     BacklogStatus `json:"status"`
+	AddedAt    int64         `json:"added_at"`
+	StartedAt  int64         `json:"started_at,omitempty"`
+	FinishedAt int64         `json:"finished_at,omitempty"`
+}
+
+// WatchStatus describes where the user is with a show they've started
+type WatchStatus string
+
+const (
+	Watching WatchStatus = "watching"
+	Paused   WatchStatus = "paused"
+	Finished WatchStatus = "finished"
+)
+
+// TVProgress tracks how far the user is through a TV show; Season and Episode
+// refer to the most recently watched episode, with 0 meaning not yet started
+type TVProgress struct {
+	Show      TVShow      `json:"show"`
+	ShowID    int         `json:"show_id"` // TMDB ID, used to look up seasons and episodes
+	Season    int         `json:"season"`
+	Episode   int         `json:"episode"`
+	Status    WatchStatus `json:"status"`
+	UpdatedAt int64       `json:"updated_at"`
+}
+
+// Progress stores the user's viewing progress for episodic media
+type Progress struct {
+	TVShows []TVProgress `json:"tv_shows"`
+	// NotifiedEpisodes are the TMDB IDs of episodes the user's been alerted to, with their air dates
+	NotifiedEpisodes map[int]string `json:"notified_episodes,omitempty"`
 }
 
 type Comparator[T Media] func(Suggestion[T], Suggestion[T]) bool
@@ -15,8 +15,9 @@ func sanitizeKey(s string) string {
 
 // Keyer functions are necessary since results from remote sources won't be the session.Media type
 
+// KeyerMusicInfo leaves title empty for an album, and both title and album empty for an artist
 func KeyerMusicInfo(title, artist, album string) string {
-	return sanitizeKey(fmt.Sprintf("%s_%s_%s", title, artist, album))
+	return Music{Title: title, Artist: artist, Album: album}.Key()
 }
 
 func KeyerMovieInfo(title, director, writer string) string {
@@ -4,20 +4,24 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"interestnaut/internal/creds"
+	"interestnaut/internal/endpoints"
 	"interestnaut/internal/server"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os/exec"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	request "github.com/catlee993/go-request"
@@ -26,22 +30,41 @@ import (
 
 // Constants remain mostly unchanged
 const (
-	ClientID    = "3bb48a30577342869a9ffcb176dee7d2"
-	authURL     = "https://accounts.spotify.com/authorize"
-	redirectURI = "http://localhost:8080/callback"
-	scope       = "user-read-private user-read-email user-library-read user-library-modify user-read-playback-state user-modify-playback-state streaming"
-	tokenURL    = "https://accounts.spotify.com/api/token"
+	ClientID     = "3bb48a30577342869a9ffcb176dee7d2"
+	callbackPath = "/callback"
+	authTimeout  = 5 * time.Minute
+	scope        = "user-read-private user-read-email user-library-read user-library-modify user-read-playback-state user-modify-playback-state streaming playlist-read-private playlist-modify-private playlist-modify-public user-top-read user-read-recently-played"
 )
 
+// callbackPorts are tried in order for the loopback callback server; each must have a matching
+// http://127.0.0.1:<port>/callback redirect URI registered for the Spotify client
+var callbackPorts = []int{8080, 8081, 8888, 9090}
+
 var (
 	tokenMutex  sync.RWMutex
 	accessToken string
 	tokenExpiry time.Time
+	// grantedScope is what the current token was issued for, as Spotify reported it
+	grantedScope string
 
 	// codeVerifier for PKCE (global so it can be referenced during token exchange)
 	codeVerifier string
+
+	// flight is the browser flow in progress, if any, shared by everyone who needs it so only one window opens
+	flight   *authFlight
+	flightMu sync.Mutex
 )
 
+// errConsentNeeded means there's no way to get a token without the user going through the browser flow again
+var errConsentNeeded = errors.New("spotify consent is needed")
+
+// authFlight is a browser flow that callers can wait on; token and err are set before done is closed
+type authFlight struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
 // generateCodeVerifier returns a cryptographically random string for PKCE
 func generateCodeVerifier() (string, error) {
 	b := make([]byte, 64)
@@ -59,42 +82,125 @@ func computeCodeChallenge(verifier string) (string, error) {
 }
 
 // RunInitialAuthFlow starts a local server, opens the browser to start OAuth with PKCE, and waits
-// for callback to save credentials.
+// for callback to save credentials. Failures are returned as *AuthError.
 func RunInitialAuthFlow(ctx context.Context) error {
+	_, err := authenticate(ctx)
+	return err
+}
+
+// authenticate runs the browser flow and caches the access token it gets. A flow that's already open is
+// waited on rather than starting another, so the user only sees one browser window. Callers must not hold
+// tokenMutex, since the user can take as long as authTimeout.
+func authenticate(ctx context.Context) (string, error) {
+	flightMu.Lock()
+	if f := flight; f != nil {
+		flightMu.Unlock()
+		select {
+		case <-f.done:
+			return f.token, f.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	f := &authFlight{done: make(chan struct{})}
+	flight = f
+	flightMu.Unlock()
+
+	defer func() {
+		flightMu.Lock()
+		flight = nil
+		flightMu.Unlock()
+		close(f.done)
+	}()
+
+	authResp, err := runAuthFlow(ctx)
+	if err != nil {
+		f.err = err
+		return "", err
+	}
+
+	// Store the access token and its expiry in memory only
+	tokenMutex.Lock()
+	storeAccessToken(authResp)
+	f.token = accessToken
+	tokenMutex.Unlock()
+
+	return f.token, nil
+}
+
+// runAuthFlow performs the browser flow and saves the refresh token, leaving the in-memory
+// access token to the caller
+func runAuthFlow(ctx context.Context) (*AuthResponse, error) {
+	ln, err := server.ListenLoopback(callbackPorts)
+	if err != nil {
+		return nil, newAuthError(AuthPortUnavailable, "could not start the local callback server", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	redirect := fmt.Sprintf("http://127.0.0.1:%d%s", port, callbackPath)
+
+	state, err := generateState()
+	if err != nil {
+		_ = ln.Close()
+		return nil, newAuthError(AuthBrowserFailed, "failed to generate state", err)
+	}
+
 	stop := make(chan struct{})
+	resultChan := make(chan *AuthResponse, 1)
 	errChan := make(chan error, 1)
+	var once sync.Once
+	finish := func() { once.Do(func() { close(stop) }) }
+	var mismatched atomic.Bool // Set when a callback was ignored for its state, to explain a timeout
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-		code := r.URL.Query().Get("code")
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		// Reject callbacks that we didn't initiate before looking at anything else. They may be a stale tab or
+		// the browser prefetching, so the login still in progress is waited for rather than abandoned.
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			log.Printf("WARNING: Ignoring auth callback with a mismatched state")
+			mismatched.Store(true)
+			http.Error(w, "Invalid 'state' parameter in callback", http.StatusBadRequest)
+			return
+		}
+
+		if authErr := query.Get("error"); authErr != "" {
+			http.Error(w, "Authorization was not granted", http.StatusForbidden)
+			select {
+			case errChan <- newAuthError(AuthDenied, "Spotify authorization was not granted ("+authErr+")", nil):
+			default:
+			}
+			return
+		}
+
+		code := query.Get("code")
 		if code == "" {
-			errChan <- fmt.Errorf("missing 'code' parameter in callback")
+			log.Printf("WARNING: Ignoring auth callback without a code")
 			http.Error(w, "Missing 'code' parameter in callback", http.StatusBadRequest)
 			return
 		}
 
 		// Exchange the authorization code for tokens using PKCE
-		authResp, err := exchangeCodeForToken(ctx, ClientID, code)
+		authResp, err := exchangeCodeForToken(ctx, ClientID, code, redirect)
 		if err != nil {
-			errChan <- fmt.Errorf("failed to exchange code for token: %w", err)
 			http.Error(w, fmt.Sprintf("Failed to exchange code for token: %v", err), http.StatusInternalServerError)
+			select {
+			case errChan <- newAuthError(AuthExchangeFailed, "failed to exchange code for token", err):
+			default:
+			}
 			return
 		}
 
 		// Save the refresh token in the keychain
 		if err := creds.SaveSpotifyToken(authResp.RefreshToken); err != nil {
-			errChan <- fmt.Errorf("failed to save refresh token: %w", err)
 			http.Error(w, "Failed to save refresh token", http.StatusInternalServerError)
+			select {
+			case errChan <- newAuthError(AuthExchangeFailed, "failed to save refresh token", err):
+			default:
+			}
 			return
 		}
 
-		// Store the access token and its expiry in memory only
-		tokenMutex.Lock()
-		accessToken = authResp.AccessToken
-		tokenExpiry = time.Now().Add(time.Duration(authResp.ExpiresIn) * time.Second)
-		log.Printf("DEBUG (Callback): Stored initial token expiring at %s", tokenExpiry.Format(time.RFC3339))
-		tokenMutex.Unlock()
-
 		// Send success response to browser
 		w.Header().Set("Content-Type", "text/html")
 		successHTML := `
@@ -114,52 +220,67 @@ func RunInitialAuthFlow(ctx context.Context) error {
 		_, _ = fmt.Fprint(w, successHTML)
 
 		// Signal successful completion
-		close(stop)
+		select {
+		case resultChan <- authResp:
+		default:
+		}
+		finish()
 	})
 
-	serverCtx, serverCancel := context.WithCancel(ctx)
-	defer serverCancel()
+	flowCtx, flowCancel := context.WithTimeout(ctx, authTimeout)
+	defer flowCancel()
 
 	// Start server and handle errors
 	serverErrChan := make(chan error, 1)
 	go func() {
-		if err := server.Start(serverCtx, stop, mux); err != nil {
+		if err := server.Start(flowCtx, stop, ln, mux); err != nil {
 			serverErrChan <- err
 		}
 	}()
+	defer finish() // Ensure server is stopped
 
 	// Start auth flow in browser
-	if err := startAuth(ClientID); err != nil {
-		serverCancel() // Cancel server context if browser launch fails
-		return fmt.Errorf("failed to start auth flow: %w", err)
+	if err := startAuth(ClientID, redirect, state); err != nil {
+		return nil, newAuthError(AuthBrowserFailed, "failed to start auth flow", err)
 	}
 
-	// Wait for either success, error, or context cancellation
+	// Wait for either success, error, timeout or cancellation
 	select {
-	case <-stop:
+	case authResp := <-resultChan:
 		log.Println("Authentication successful")
-		serverCancel() // Ensure server is stopped
-		// Wait for server to stop
-		select {
-		case err := <-serverErrChan:
-			if err != nil && !errors.Is(err, http.ErrServerClosed) {
-				return fmt.Errorf("server error during shutdown: %w", err)
-			}
-		case <-time.After(5 * time.Second):
-			// Timeout waiting for server shutdown
-		}
-		return nil
+		return authResp, nil
 	case err := <-errChan:
-		serverCancel() // Ensure server is stopped
-		return err
+		return nil, err
 	case err := <-serverErrChan:
-		return fmt.Errorf("server error: %w", err)
-	case <-ctx.Done():
-		serverCancel() // Ensure server is stopped
-		return ctx.Err()
+		return nil, newAuthError(AuthPortUnavailable, "callback server error", err)
+	case <-flowCtx.Done():
+		if errors.Is(flowCtx.Err(), context.DeadlineExceeded) {
+			if mismatched.Load() {
+				return nil, newAuthError(AuthStateMismatch, "callback state did not match the request", nil)
+			}
+			return nil, newAuthError(AuthTimeout, fmt.Sprintf("no response from Spotify within %s", authTimeout), nil)
+		}
+		return nil, newAuthError(AuthCancelled, "authentication was cancelled", ctx.Err())
 	}
 }
 
+// storeAccessToken caches a freshly issued access token; callers must hold tokenMutex
+func storeAccessToken(authResp *AuthResponse) {
+	accessToken = authResp.AccessToken
+	tokenExpiry = time.Now().Add(time.Duration(authResp.ExpiresIn) * time.Second)
+	grantedScope = authResp.Scope
+	log.Printf("DEBUG: Stored token expiring at %s", tokenExpiry.Format(time.RFC3339))
+}
+
+// generateState returns a random value to tie the callback to the request we made
+func generateState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 // ClearSpotifyCredentials clears stored Spotify tokens and resets in-memory state.
 func ClearSpotifyCredentials(ctx context.Context) error {
 	log.Println("Attempting to clear Spotify credentials...")
@@ -172,6 +293,7 @@ func ClearSpotifyCredentials(ctx context.Context) error {
 	tokenMutex.Lock()
 	accessToken = ""
 	tokenExpiry = time.Time{}
+	grantedScope = ""
 	tokenMutex.Unlock()
 	log.Println("Cleared Spotify credentials from storage and memory.")
 
@@ -184,10 +306,11 @@ func ClearSpotifyCredentials(ctx context.Context) error {
 	return nil
 }
 
-// GetValidToken retrieves a valid Spotify access token, refreshing if necessary.
+// GetValidToken retrieves a valid Spotify access token, refreshing if necessary. When the refresh token
+// can't be used, the browser flow is started to get a new one.
 func GetValidToken(ctx context.Context) (string, error) {
 	tokenMutex.RLock()
-	if accessToken != "" && time.Now().Before(tokenExpiry) {
+	if tokenUsable() {
 		acToken := accessToken
 		tokenMutex.RUnlock()
 		return acToken, nil
@@ -196,39 +319,40 @@ func GetValidToken(ctx context.Context) (string, error) {
 
 	// If token is invalid or expired, acquire write lock to refresh
 	tokenMutex.Lock()
-	defer tokenMutex.Unlock()
+	token, err := refreshAccessToken(ctx)
+	tokenMutex.Unlock()
+
+	// The browser flow runs without the lock, so other callers aren't stuck behind the user
+	if errors.Is(err, errConsentNeeded) {
+		log.Printf("ERROR: %v, starting a new auth flow", err)
+		token, authErr := authenticate(ctx)
+		if authErr != nil {
+			log.Printf("ERROR: Failed to start new auth flow: %v", authErr)
+			return "", fmt.Errorf("%v, and failed to re-authenticate: %w", err, authErr)
+		}
+		return token, nil
+	}
+
+	return token, err
+}
 
+// refreshAccessToken gets a new access token with the stored refresh token, returning errConsentNeeded when
+// that isn't possible; callers must hold tokenMutex
+func refreshAccessToken(ctx context.Context) (string, error) {
 	// Double-check expiry after acquiring write lock
-	if accessToken != "" && time.Now().Before(tokenExpiry) {
+	if tokenUsable() {
 		return accessToken, nil
 	}
 
 	refreshToken, err := creds.GetSpotifyToken()
 	if err != nil {
 		log.Printf("ERROR: Failed to get refresh token from storage: %v", err)
-		// Instead of just returning an error, try to start a new auth flow
-		if err := RunInitialAuthFlow(ctx); err != nil {
-			log.Printf("ERROR: Failed to start new auth flow after refresh token retrieval error: %v", err)
-			return "", fmt.Errorf("failed to refresh token and re-authenticate: %w", err)
-		}
-		// If auth flow successful, try to get the token again
-		newToken, tokenErr := creds.GetSpotifyToken()
-		if tokenErr != nil {
-			return "", ErrNotAuthenticated
-		}
-		refreshToken = newToken
+		return "", fmt.Errorf("%w: %v", errConsentNeeded, err)
 	}
 
 	if refreshToken == "" {
 		log.Println("ERROR: Retrieved empty refresh token from storage.")
-		// Start new auth flow if refresh token is empty
-		if err := RunInitialAuthFlow(ctx); err != nil {
-			log.Printf("ERROR: Failed to start new auth flow after empty refresh token: %v", err)
-			return "", fmt.Errorf("failed to re-authenticate with empty refresh token: %w", err)
-		}
-		// If successful, we need to wait a moment for the new token to be saved
-		time.Sleep(1 * time.Second)
-		return GetValidToken(ctx) // Retry with the new credentials
+		return "", fmt.Errorf("%w: the stored refresh token is empty", errConsentNeeded)
 	}
 
 	form := url.Values{}
@@ -236,7 +360,7 @@ func GetValidToken(ctx context.Context) (string, error) {
 	form.Set("refresh_token", refreshToken)
 	form.Set("client_id", ClientID)
 
-	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoints.Get(endpoints.SpotifyAccounts).URL()+"/api/token", strings.NewReader(form.Encode()))
 	if err != nil {
 		log.Printf("ERROR: Failed to create token refresh request: %v", err)
 		return "", fmt.Errorf("failed to create token refresh request: %w", err)
@@ -262,28 +386,8 @@ func GetValidToken(ctx context.Context) (string, error) {
 		log.Printf("ERROR: Token refresh failed with status %d: %s", resp.StatusCode, string(body))
 		if strings.Contains(string(body), "invalid_grant") {
 			log.Println("ERROR: Invalid refresh token (invalid_grant). Clearing stored credentials and initiating re-auth.")
-			// Clear invalid credentials
-			if err := keyring.Delete(creds.ServiceName, creds.SpotifyRefreshTokenKey); err != nil {
-				log.Printf("ERROR: Failed to clear invalid credentials from keyring: %v", err)
-			}
-			accessToken = ""
-			tokenExpiry = time.Time{}
-
-			// Automatically start a new auth flow
-			if err := RunInitialAuthFlow(ctx); err != nil {
-				log.Printf("ERROR: Failed to start new auth flow after invalid_grant: %v", err)
-				return "", fmt.Errorf("invalid refresh token and failed to re-authenticate: %w", err)
-			}
-
-			// If the auth flow completes, try to get a token again with the new credentials
-			// We need a short delay to ensure the token is properly saved by the callback
-			time.Sleep(1 * time.Second)
-
-			// Re-acquire the lock since we'll release it when we recursively call GetValidToken
-			tokenMutex.Unlock()
-			defer tokenMutex.Lock()
-
-			return GetValidToken(ctx)
+			forgetTokens()
+			return "", fmt.Errorf("%w: the refresh token is invalid", errConsentNeeded)
 		}
 		return "", fmt.Errorf("token refresh failed with status %d: %s", resp.StatusCode, string(body))
 	}
@@ -299,9 +403,15 @@ func GetValidToken(ctx context.Context) (string, error) {
 		return "", ErrNotAuthenticated
 	}
 
+	// Tokens granted before the app asked for more scopes keep refreshing with the old ones, so the user has
+	// to consent again for features such as playlists to work
+	if missing := missingScopes(authResp.Scope); len(missing) > 0 {
+		forgetTokens()
+		return "", fmt.Errorf("%w: the token is missing scopes %s", errConsentNeeded, strings.Join(missing, ", "))
+	}
+
 	// Update cached token and expiry
-	accessToken = authResp.AccessToken
-	tokenExpiry = time.Now().Add(time.Duration(authResp.ExpiresIn) * time.Second)
+	storeAccessToken(&authResp)
 	log.Printf("DEBUG: Successfully refreshed token, new expiry: %s", tokenExpiry.Format(time.RFC3339))
 
 	// If the response included a *new* refresh token, update storage
@@ -314,8 +424,59 @@ func GetValidToken(ctx context.Context) (string, error) {
 	return accessToken, nil
 }
 
+// tokenUsable reports whether the cached access token hasn't expired and has every scope the app needs;
+// callers must hold tokenMutex
+func tokenUsable() bool {
+	return accessToken != "" && time.Now().Before(tokenExpiry) && len(missingScopes(grantedScope)) == 0
+}
+
+// missingScopes returns the scopes the app needs that a token wasn't granted. Nothing is missing when
+// Spotify didn't say what was granted.
+func missingScopes(granted string) []string {
+	if strings.TrimSpace(granted) == "" {
+		return nil
+	}
+
+	have := make(map[string]bool)
+	for _, s := range strings.Fields(granted) {
+		have[s] = true
+	}
+
+	var missing []string
+	for _, s := range strings.Fields(scope) {
+		if !have[s] {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// forgetTokens clears the stored refresh token and the cached access token, so the next token comes from a
+// new auth flow; callers must hold tokenMutex
+func forgetTokens() {
+	if err := keyring.Delete(creds.ServiceName, creds.SpotifyRefreshTokenKey); err != nil {
+		log.Printf("ERROR: Failed to clear invalid credentials from keyring: %v", err)
+	}
+	accessToken = ""
+	tokenExpiry = time.Time{}
+	grantedScope = ""
+}
+
+// invalidateAccessToken drops the cached access token after Spotify rejects it, so the next
+// GetValidToken refreshes it. A token that's already been replaced by another caller is left alone.
+func invalidateAccessToken(token string) {
+	tokenMutex.Lock()
+	defer tokenMutex.Unlock()
+
+	if accessToken == token {
+		accessToken = ""
+		tokenExpiry = time.Time{}
+	}
+}
+
 // exchangeCodeForToken exchanges an authorization code for access and refresh tokens using PKCE.
-func exchangeCodeForToken(ctx context.Context, clientID, code string) (*AuthResponse, error) {
+// redirectURI must match the one the authorization was requested with.
+func exchangeCodeForToken(ctx context.Context, clientID, code, redirectURI string) (*AuthResponse, error) {
 	values := url.Values{}
 	values.Set("grant_type", "authorization_code")
 	values.Set("code", code)
@@ -333,9 +494,9 @@ func makeTokenRequest(ctx context.Context, values url.Values) (*AuthResponse, er
 	body := values.Encode()
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.SpotifyAccounts).RequestScheme()),
 		request.WithMethod(request.Post),
-		request.WithHost("accounts.spotify.com"),
+		request.WithHost(endpoints.Get(endpoints.SpotifyAccounts).Address()),
 		request.WithPath("api", "token"),
 		request.WithBody([]byte(body)),
 		request.WithHeaders(map[string][]string{
@@ -357,7 +518,7 @@ func makeTokenRequest(ctx context.Context, values url.Values) (*AuthResponse, er
 
 // startAuth initiates the OAuth flow with PKCE by constructing the authorization URL (including a code challenge)
 // and opening it in the default browser.
-func startAuth(clientID string) error {
+func startAuth(clientID, redirectURI, state string) error {
 	var err error
 	// Generate PKCE code verifier
 	codeVerifier, err = generateCodeVerifier()
@@ -371,18 +532,20 @@ func startAuth(clientID string) error {
 		return fmt.Errorf("failed to compute code challenge: %w", err)
 	}
 
-	signinUrl := fmt.Sprintf("%s?client_id=%s&response_type=code&redirect_uri=%s&scope=%s&code_challenge=%s&code_challenge_method=S256",
-		authURL,
+	signinUrl := fmt.Sprintf("%s?client_id=%s&response_type=code&redirect_uri=%s&scope=%s&state=%s&code_challenge=%s&code_challenge_method=S256",
+		endpoints.Get(endpoints.SpotifyAccounts).URL()+"/authorize",
 		url.QueryEscape(clientID),
 		url.QueryEscape(redirectURI),
 		url.QueryEscape(scope),
+		url.QueryEscape(state),
 		url.QueryEscape(codeChallenge),
 	)
 	return openBrowser(signinUrl)
 }
 
-// openBrowser opens the default browser with the given URL.
-func openBrowser(url string) error {
+// openBrowser opens the default browser with the given URL. It's a variable so tests can stand in for the
+// browser.
+var openBrowser = func(url string) error {
 	var cmd string
 	var args []string
 
@@ -0,0 +1,208 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"interestnaut/internal/creds"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		granted string
+		want    []string
+	}{
+		{"everything", scope, nil},
+		{"reordered", "user-read-recently-played user-top-read playlist-modify-public playlist-modify-private " +
+			"playlist-read-private streaming user-modify-playback-state user-read-playback-state " +
+			"user-library-modify user-library-read user-read-email user-read-private", nil},
+		{"not reported", "", nil},
+		{"granted before playlists and history", "user-read-private user-read-email user-library-read " +
+			"user-library-modify user-read-playback-state user-modify-playback-state streaming",
+			[]string{"playlist-read-private", "playlist-modify-private", "playlist-modify-public", "user-top-read", "user-read-recently-played"}},
+		{"one missing", "user-read-private user-read-email user-library-read user-library-modify " +
+			"user-read-playback-state user-modify-playback-state streaming playlist-read-private " +
+			"playlist-modify-private playlist-modify-public user-top-read", []string{"user-read-recently-played"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingScopes(tt.granted); !slices.Equal(got, tt.want) {
+				t.Errorf("missingScopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetValidTokenWaitsForAuthFlow(t *testing.T) {
+	if err := creds.ClearSpotifyToken(); err != nil {
+		t.Fatalf("failed to clear refresh token: %v", err)
+	}
+	tokenMutex.Lock()
+	accessToken, tokenExpiry, grantedScope = "", time.Time{}, ""
+	tokenMutex.Unlock()
+
+	// Stands in for a browser flow that's waiting on the user
+	f := &authFlight{done: make(chan struct{})}
+	flightMu.Lock()
+	flight = f
+	flightMu.Unlock()
+
+	const callers = 3
+	results := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			token, err := GetValidToken(context.Background())
+			if err != nil {
+				t.Errorf("GetValidToken() failed: %v", err)
+			}
+			results <- token
+		}()
+	}
+
+	// Give the callers time to start waiting, then check the token isn't locked while the user's in the browser
+	time.Sleep(100 * time.Millisecond)
+	locked := make(chan struct{})
+	go func() {
+		tokenMutex.Lock()
+		tokenMutex.Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tokenMutex was held while waiting on the auth flow")
+	}
+	select {
+	case token := <-results:
+		t.Fatalf("GetValidToken() returned %q before the auth flow finished", token)
+	default:
+	}
+
+	// Finish the flow as authenticate would
+	tokenMutex.Lock()
+	accessToken, tokenExpiry, grantedScope = "from-browser", time.Now().Add(time.Hour), scope
+	tokenMutex.Unlock()
+	flightMu.Lock()
+	flight = nil
+	flightMu.Unlock()
+	f.token = "from-browser"
+	close(f.done)
+
+	for i := 0; i < callers; i++ {
+		select {
+		case token := <-results:
+			if token != "from-browser" {
+				t.Errorf("GetValidToken() = %q, want the token from the auth flow", token)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("GetValidToken() didn't return after the auth flow finished")
+		}
+	}
+}
+
+func TestRunAuthFlowIgnoresStrayCallbacks(t *testing.T) {
+	tests := []struct {
+		name      string
+		callbacks []url.Values // Sent in turn; a state of "valid" is replaced with the flow's own
+		wantCodes []int        // What each callback is answered with
+		wantErr   AuthErrorCode
+	}{
+		{
+			name: "stray callbacks before the login",
+			callbacks: []url.Values{
+				{},
+				{"state": {"stale"}, "code": {"old-code"}},
+				{"state": {"valid"}},
+				{"state": {"valid"}, "code": {"code"}},
+			},
+			wantCodes: []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusOK},
+		},
+		{
+			name: "denial from another request",
+			callbacks: []url.Values{
+				{"state": {"stale"}, "error": {"access_denied"}},
+				{"state": {"valid"}, "code": {"code"}},
+			},
+			wantCodes: []int{http.StatusBadRequest, http.StatusOK},
+		},
+		{
+			name: "denied",
+			callbacks: []url.Values{
+				{"state": {"stale"}, "code": {"old-code"}},
+				{"state": {"valid"}, "error": {"access_denied"}},
+			},
+			wantCodes: []int{http.StatusBadRequest, http.StatusForbidden},
+			wantErr:   AuthDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testServer.mu.Lock()
+			testServer.refresh = 0
+			testServer.mu.Unlock()
+
+			// Stands in for the browser, sending each callback to the redirect URI
+			var codes []int
+			browsed := make(chan error, 1)
+			defer func(open func(string) error) { openBrowser = open }(openBrowser)
+			openBrowser = func(signin string) error {
+				u, err := url.Parse(signin)
+				if err != nil {
+					return err
+				}
+				redirect, state := u.Query().Get("redirect_uri"), u.Query().Get("state")
+
+				go func() {
+					for _, callback := range tt.callbacks {
+						query := url.Values{}
+						for k, v := range callback {
+							query[k] = v
+						}
+						if query.Get("state") == "valid" {
+							query.Set("state", state)
+						}
+
+						resp, err := http.Get(redirect + "?" + query.Encode())
+						if err != nil {
+							browsed <- err
+							return
+						}
+						_ = resp.Body.Close()
+						codes = append(codes, resp.StatusCode)
+					}
+					browsed <- nil
+				}()
+				return nil
+			}
+
+			authResp, err := runAuthFlow(context.Background())
+			if bErr := <-browsed; bErr != nil {
+				t.Fatalf("callback failed: %v", bErr)
+			}
+			if !slices.Equal(codes, tt.wantCodes) {
+				t.Errorf("callbacks were answered with %v, want %v", codes, tt.wantCodes)
+			}
+
+			if tt.wantErr != "" {
+				var authErr *AuthError
+				if !errors.As(err, &authErr) || authErr.Code != tt.wantErr {
+					t.Errorf("runAuthFlow() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runAuthFlow() failed: %v", err)
+			}
+			if authResp.AccessToken != "fresh-1" {
+				t.Errorf("access token = %q, want %q", authResp.AccessToken, "fresh-1")
+			}
+		})
+	}
+}
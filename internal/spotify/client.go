@@ -2,12 +2,11 @@ package spotify
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -22,21 +21,43 @@ type Client interface {
 	GetCurrentUser(ctx context.Context) (*UserProfile, error)
 	GetSavedTracks(ctx context.Context, limit, offset int) (*SavedTracks, error)
 	SearchTracks(ctx context.Context, query string, limit int) ([]*SimpleTrack, error)
+	SearchAlbums(ctx context.Context, query string, limit int) ([]*SimpleAlbum, error)
+	SearchArtists(ctx context.Context, query string, limit int) ([]*SimpleArtist, error)
 	SaveTrack(ctx context.Context, trackID string) error
 	RemoveTrack(ctx context.Context, trackID string) error
 	GetTrackDetails(ctx context.Context, trackID string, market string) (*Track, error)
 	PlayTrackOnDevice(ctx context.Context, deviceID string, trackURI string) error
 	PausePlaybackOnDevice(ctx context.Context, deviceID string) error
 	GetAllLikedTracks(ctx context.Context) ([]SavedTrackItem, error)
+	GetCurrentUserPlaylists(ctx context.Context, limit, offset int) (*Playlists, error)
+	CreatePlaylist(ctx context.Context, userID, name, description string, public bool) (*Playlist, error)
+	GetPlaylistItems(ctx context.Context, playlistID string, limit, offset int) (*PlaylistItems, error)
+	AddItemsToPlaylist(ctx context.Context, playlistID string, uris []string) error
+	RemoveItemsFromPlaylist(ctx context.Context, playlistID string, uris []string) error
+	GetTopArtists(ctx context.Context, timeRange TimeRange, limit int) (*TopArtists, error)
+	GetTopTracks(ctx context.Context, timeRange TimeRange, limit int) (*TopTracks, error)
+	GetRecentlyPlayed(ctx context.Context, limit int) (*RecentlyPlayed, error)
+	GetArtists(ctx context.Context, ids []string) ([]Artist, error)
+	GetDevices(ctx context.Context) ([]Device, error)
+	TransferPlayback(ctx context.Context, deviceID string, play bool) error
+	AddToQueue(ctx context.Context, deviceID string, uri string) error
+	GetPlaybackState(ctx context.Context) (*PlaybackState, error)
 }
 
 // client represents a Spotify API client.
 type client struct {
 	cli        *http.Client
 	authConfig *AuthConfig
+
+	// artistCache holds looked up artists by ID, since their genres rarely change
+	artistCache map[string]Artist
+	artistMu    sync.RWMutex
 }
 
-const authRedirectURI = "http://localhost:8080/callback"
+// maxArtistsPerRequest is the most IDs Spotify accepts in a single /artists lookup
+const maxArtistsPerRequest = 50
+
+const authRedirectURI = "http://127.0.0.1:8080/callback"
 const spotifyClientID = "3bb48a30577342869a9ffcb176dee7d2"
 
 // NewClient creates a new Spotify API client with default settings.
@@ -47,39 +68,24 @@ func NewClient() Client {
 		RedirectURI: authRedirectURI,
 	}
 	return &client{
-		cli:        http.DefaultClient,
-		authConfig: authConfig,
+		cli:         http.DefaultClient,
+		authConfig:  authConfig,
+		artistCache: make(map[string]Artist),
 	}
 }
 
 // GetSavedTracks retrieves the user's saved tracks.
 func (c *client) GetSavedTracks(ctx context.Context, limit, offset int) (*SavedTracks, error) {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Get),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "me", "tracks"),
-		request.WithQueryArgs(map[string][]string{
+	var tracks SavedTracks
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me", "tracks"},
+		query: map[string][]string{
 			"limit":  {fmt.Sprintf("%d", limit)},
 			"offset": {fmt.Sprintf("%d", offset)},
-		}),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-		}),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create requester: %w", err)
-	}
-
-	var tracks SavedTracks
-	_, rErr := req.Make(ctx, &tracks)
-	if rErr != nil {
-		return nil, fmt.Errorf("request failed: %w", rErr)
+		},
+	}, &tracks); err != nil {
+		return nil, fmt.Errorf("failed to get saved tracks: %w", err)
 	}
 
 	return &tracks, nil
@@ -87,84 +93,135 @@ func (c *client) GetSavedTracks(ctx context.Context, limit, offset int) (*SavedT
 
 // SearchTracks searches for tracks matching the query.
 func (c *client) SearchTracks(ctx context.Context, query string, limit int) ([]*SimpleTrack, error) {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Get),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "search"),
-		request.WithQueryArgs(map[string][]string{
+	var results SearchResults
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "search"},
+		query: map[string][]string{
 			"q":     {query},
 			"type":  {"track"},
 			"limit": {fmt.Sprintf("%d", limit)},
-		}),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-		}),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create requester: %w", err)
-	}
-
-	var results SearchResults
-	_, rErr := req.Make(ctx, &results)
-	if rErr != nil {
-		return nil, fmt.Errorf("request failed: %w", rErr)
+		},
+	}, &results); err != nil {
+		return nil, fmt.Errorf("failed to search tracks: %w", err)
 	}
 
 	// Convert to SimpleTrack array
 	simpleTracks := make([]*SimpleTrack, 0, len(results.Tracks.Items))
 	for _, track := range results.Tracks.Items {
-		simpleTrack := &SimpleTrack{
-			ID:         track.ID,
-			Name:       track.Name,
-			PreviewUrl: track.PreviewUrl,
-			URI:        track.URI,
+		simpleTracks = append(simpleTracks, ToSimpleTrack(track))
+	}
+
+	return simpleTracks, nil
+}
+
+// SearchAlbums searches for albums matching the query.
+func (c *client) SearchAlbums(ctx context.Context, query string, limit int) ([]*SimpleAlbum, error) {
+	var results SearchResults
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "search"},
+		query: map[string][]string{
+			"q":     {query},
+			"type":  {"album"},
+			"limit": {fmt.Sprintf("%d", limit)},
+		},
+	}, &results); err != nil {
+		return nil, fmt.Errorf("failed to search albums: %w", err)
+	}
+
+	albums := make([]*SimpleAlbum, 0, len(results.Albums.Items))
+	for _, album := range results.Albums.Items {
+		// Spotify sometimes pads results with nulls
+		if album == nil {
+			continue
 		}
-		if len(track.Artists) > 0 {
-			simpleTrack.Artist = track.Artists[0].Name
-			simpleTrack.ArtistID = track.Artists[0].ID
+		simpleAlbum := &SimpleAlbum{
+			ID:          album.ID,
+			Name:        album.Name,
+			ReleaseDate: album.ReleaseDate,
+			TotalTracks: album.TotalTracks,
+			URI:         album.URI,
 		}
-		if len(track.Album.Images) > 0 {
-			simpleTrack.AlbumArtUrl = track.Album.Images[0].URL
+		if len(album.Artists) > 0 {
+			simpleAlbum.Artist = album.Artists[0].Name
+			simpleAlbum.ArtistID = album.Artists[0].ID
 		}
-		simpleTrack.Album = track.Album.Name
-		simpleTrack.AlbumID = track.Album.ID
-
-		simpleTracks = append(simpleTracks, simpleTrack)
+		if len(album.Images) > 0 {
+			simpleAlbum.AlbumArtUrl = album.Images[0].URL
+		}
+		albums = append(albums, simpleAlbum)
 	}
 
-	return simpleTracks, nil
+	return albums, nil
 }
 
-// SaveTrack saves a track to the user's library.
-func (c *client) SaveTrack(ctx context.Context, trackID string) error {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		return err
+// SearchArtists searches for artists matching the query.
+func (c *client) SearchArtists(ctx context.Context, query string, limit int) ([]*SimpleArtist, error) {
+	var results SearchResults
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "search"},
+		query: map[string][]string{
+			"q":     {query},
+			"type":  {"artist"},
+			"limit": {fmt.Sprintf("%d", limit)},
+		},
+	}, &results); err != nil {
+		return nil, fmt.Errorf("failed to search artists: %w", err)
 	}
 
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Put),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "me", "tracks"),
-		request.WithQueryArgs(map[string][]string{
-			"ids": {trackID},
-		}),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-		}),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create requester: %w", err)
+	artists := make([]*SimpleArtist, 0, len(results.Artists.Items))
+	for _, artist := range results.Artists.Items {
+		if artist == nil {
+			continue
+		}
+		simpleArtist := &SimpleArtist{
+			ID:         artist.ID,
+			Name:       artist.Name,
+			Genres:     artist.Genres,
+			Popularity: artist.Popularity,
+			URI:        artist.URI,
+		}
+		if len(artist.Images) > 0 {
+			simpleArtist.ImageUrl = artist.Images[0].URL
+		}
+		artists = append(artists, simpleArtist)
 	}
 
-	if _, err := req.Make(ctx, nil); err != nil {
+	return artists, nil
+}
+
+// ToSimpleTrack flattens a track into the representation the frontend uses
+func ToSimpleTrack(track *Track) *SimpleTrack {
+	simpleTrack := &SimpleTrack{
+		ID:         track.ID,
+		Name:       track.Name,
+		PreviewUrl: track.PreviewUrl,
+		URI:        track.URI,
+		Album:      track.Album.Name,
+		AlbumID:    track.Album.ID,
+		Popularity: track.Popularity,
+	}
+	if len(track.Artists) > 0 {
+		simpleTrack.Artist = track.Artists[0].Name
+		simpleTrack.ArtistID = track.Artists[0].ID
+	}
+	if len(track.Album.Images) > 0 {
+		simpleTrack.AlbumArtUrl = track.Album.Images[0].URL
+	}
+	return simpleTrack
+}
+
+// SaveTrack saves a track to the user's library.
+func (c *client) SaveTrack(ctx context.Context, trackID string) error {
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Put,
+		path:   []string{"v1", "me", "tracks"},
+		query: map[string][]string{
+			"ids": {trackID},
+		},
+	}, nil); err != nil {
 		return fmt.Errorf("failed to save track: %w", err)
 	}
 
@@ -173,28 +230,13 @@ func (c *client) SaveTrack(ctx context.Context, trackID string) error {
 
 // RemoveTrack removes a track from the user's library.
 func (c *client) RemoveTrack(ctx context.Context, trackID string) error {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		return err
-	}
-
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Delete),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "me", "tracks"),
-		request.WithQueryArgs(map[string][]string{
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Delete,
+		path:   []string{"v1", "me", "tracks"},
+		query: map[string][]string{
 			"ids": {trackID},
-		}),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-		}),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create requester: %w", err)
-	}
-
-	if _, err := req.Make(ctx, nil); err != nil {
+		},
+	}, nil); err != nil {
 		return fmt.Errorf("failed to remove track: %w", err)
 	}
 
@@ -203,203 +245,165 @@ func (c *client) RemoveTrack(ctx context.Context, trackID string) error {
 
 // GetCurrentUser retrieves the current user's profile.
 func (c *client) GetCurrentUser(ctx context.Context) (*UserProfile, error) {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Get),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "me"),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-		}),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create requester: %w", err)
-	}
-
 	var profile UserProfile
-	_, rErr := req.Make(ctx, &profile)
-	if rErr != nil {
-		return nil, fmt.Errorf("request failed: %w", rErr)
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me"},
+	}, &profile); err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
 	return &profile, nil
 }
 
-// GetTrackDetails retrieves detailed information using go-request.
+// GetTrackDetails retrieves detailed information about a track.
 func (c *client) GetTrackDetails(ctx context.Context, trackID string, market string) (*Track, error) {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		log.Printf("ERROR: GetValidToken failed in GetTrackDetails: %v", err)
-		return nil, err
-	}
-
 	queryArgs := map[string][]string{}
 	if market != "" {
 		queryArgs["market"] = []string{market}
 	}
 
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Get),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "tracks", trackID),
-		request.WithQueryArgs(queryArgs),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-		}),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create track details requester: %w", err)
-	}
-
 	var track Track
-	_, rErr := req.Make(ctx, &track)
-	if rErr != nil {
-		log.Printf("ERROR: GetTrackDetails (go-request) request failed for track %s. Error: %v", trackID, rErr)
-		return nil, fmt.Errorf("track details request failed: %w", rErr)
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "tracks", trackID},
+		query:  queryArgs,
+	}, &track); err != nil {
+		log.Printf("ERROR: GetTrackDetails request failed for track %s. Error: %v", trackID, err)
+		return nil, fmt.Errorf("track details request failed: %w", err)
 	}
 
 	return &track, nil
 }
 
-// PlayTrackOnDevice Methods Implementation
+// PlayTrackOnDevice starts playing a track on the given device. Album, artist and playlist URIs
+// are played as a context, from their first track.
 func (c *client) PlayTrackOnDevice(ctx context.Context, deviceID string, trackURI string) error {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		return err
-	}
-
 	body := map[string]interface{}{
 		"uris": []string{trackURI},
 	}
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return fmt.Errorf("failed to marshal play request body: %w", err)
+	if isContextURI(trackURI) {
+		body = map[string]interface{}{
+			"context_uri": trackURI,
+		}
 	}
 
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Put),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "me", "player", "play"),
-		request.WithQueryArgs(map[string][]string{
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Put,
+		path:   []string{"v1", "me", "player", "play"},
+		query: map[string][]string{
 			"device_id": {deviceID},
-		}),
-		request.WithBody(bodyBytes),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-			"Content-Type":  {"application/json"},
-		}),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create play request: %w", err)
+		},
+		body: body,
+	}, nil); err != nil {
+		log.Printf("ERROR: Play request failed: %v", err)
+		return fmt.Errorf("play request failed: %w", err)
 	}
 
-	resp, err := req.Make(ctx, nil)
-	if err != nil {
-		// Check if the error is due to an expired token
-		if strings.Contains(err.Error(), "401") {
-			// Clear the current token to force a refresh
-			tokenMutex.Lock()
-			accessToken = ""
-			tokenExpiry = time.Time{}
-			tokenMutex.Unlock()
-
-			// Get a fresh token
-			newToken, tokenErr := GetValidToken(ctx)
-			if tokenErr != nil {
-				return fmt.Errorf("failed to refresh token: %w", tokenErr)
-			}
-
-			// Retry the request with the new token
-			req, err = request.NewRequester(
-				request.WithScheme(request.HTTPS),
-				request.WithMethod(request.Put),
-				request.WithHost("api.spotify.com"),
-				request.WithPath("v1", "me", "player", "play"),
-				request.WithQueryArgs(map[string][]string{
-					"device_id": {deviceID},
-				}),
-				request.WithBody(bodyBytes),
-				request.WithHeaders(map[string][]string{
-					"Authorization": {"Bearer " + newToken},
-					"Content-Type":  {"application/json"},
-				}),
-			)
-			if err != nil {
-				return fmt.Errorf("failed to create retry play request: %w", err)
-			}
+	return nil
+}
 
-			resp, err = req.Make(ctx, nil)
-			if err != nil {
-				return fmt.Errorf("retry play request failed: %w", err)
-			}
-		} else {
-			log.Printf("ERROR: Play request req.Make failed: %v", err)
-			return fmt.Errorf("play request failed during Make: %w", err)
+// isContextURI reports whether a URI refers to a collection of tracks rather than a single one
+func isContextURI(uri string) bool {
+	for _, prefix := range []string{"spotify:album:", "spotify:artist:", "spotify:playlist:"} {
+		if strings.HasPrefix(uri, prefix) {
+			return true
 		}
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	return false
+}
 
-	if resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("ERROR: Play request returned unexpected status %d. Body: %s", resp.StatusCode, string(bodyBytes))
-		return fmt.Errorf("play request failed with status %d", resp.StatusCode)
+// PausePlaybackOnDevice pauses playback on the given device.
+func (c *client) PausePlaybackOnDevice(ctx context.Context, deviceID string) error {
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Put,
+		path:   []string{"v1", "me", "player", "pause"},
+		query: map[string][]string{
+			"device_id": {deviceID},
+		},
+	}, nil); err != nil {
+		log.Printf("ERROR: Pause request failed: %v", err)
+		return fmt.Errorf("pause request failed: %w", err)
 	}
 
 	return nil
 }
 
-func (c *client) PausePlaybackOnDevice(ctx context.Context, deviceID string) error {
-	token, err := GetValidToken(ctx)
-	if err != nil {
-		return err
+// GetDevices lists the Spotify Connect devices available to the user.
+func (c *client) GetDevices(ctx context.Context) ([]Device, error) {
+	var devices Devices
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me", "player", "devices"},
+	}, &devices); err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
 	}
 
-	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
-		request.WithMethod(request.Put),
-		request.WithHost("api.spotify.com"),
-		request.WithPath("v1", "me", "player", "pause"),
-		request.WithQueryArgs(map[string][]string{
-			"device_id": {deviceID},
-		}),
-		request.WithHeaders(map[string][]string{
-			"Authorization": {"Bearer " + token},
-		}),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create pause request: %w", err)
+	return devices.Devices, nil
+}
+
+// TransferPlayback moves playback to the given device, optionally starting it.
+func (c *client) TransferPlayback(ctx context.Context, deviceID string, play bool) error {
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Put,
+		path:   []string{"v1", "me", "player"},
+		body: map[string]interface{}{
+			"device_ids": []string{deviceID},
+			"play":       play,
+		},
+	}, nil); err != nil {
+		log.Printf("ERROR: Transfer request failed: %v", err)
+		return fmt.Errorf("transfer request failed: %w", err)
 	}
 
-	resp, err := req.Make(ctx, nil)
-	if err != nil {
-		log.Printf("ERROR: Pause request req.Make failed: %v", err)
-		return fmt.Errorf("pause request failed during Make: %w", err)
+	return nil
+}
+
+// AddToQueue adds a track to the end of the playback queue. An empty deviceID targets the active device.
+func (c *client) AddToQueue(ctx context.Context, deviceID string, uri string) error {
+	queryArgs := map[string][]string{
+		"uri": {uri},
+	}
+	if deviceID != "" {
+		queryArgs["device_id"] = []string{deviceID}
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("ERROR: Pause request returned unexpected status %d. Body: %s", resp.StatusCode, string(bodyBytes))
-		return fmt.Errorf("pause request failed with status %d", resp.StatusCode)
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Post,
+		path:   []string{"v1", "me", "player", "queue"},
+		query:  queryArgs,
+	}, nil); err != nil {
+		log.Printf("ERROR: Queue request failed: %v", err)
+		return fmt.Errorf("queue request failed: %w", err)
 	}
 
 	return nil
 }
 
+// GetPlaybackState retrieves the current playback state, or nil if nothing is playing anywhere.
+func (c *client) GetPlaybackState(ctx context.Context) (*PlaybackState, error) {
+	var state PlaybackState
+	status, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me", "player"},
+	}, &state)
+	if err != nil {
+		return nil, fmt.Errorf("playback state request failed: %w", err)
+	}
+
+	// Spotify answers with an empty 204 when there's no active playback
+	if status == http.StatusNoContent {
+		return nil, nil
+	}
+
+	return &state, nil
+}
+
+// GetAllLikedTracks pages through every saved track, newest first. Prefer a Library snapshot,
+// which only fetches what's changed.
 func (c *client) GetAllLikedTracks(ctx context.Context) ([]SavedTrackItem, error) {
 	var allTracks []SavedTrackItem
-	limit := 50 // Max allowed by Spotify API
+	limit := savedTracksPageSize
 	offset := 0
 
 	for {
@@ -408,7 +412,6 @@ func (c *client) GetAllLikedTracks(ctx context.Context) ([]SavedTrackItem, error
 		cancel() // Release context resources promptly
 
 		if err != nil {
-			// Consider retries for transient errors?
 			return nil, errors.Wrapf(err, "failed to get saved tracks page (offset %d)", offset)
 		}
 
@@ -423,15 +426,201 @@ func (c *client) GetAllLikedTracks(ctx context.Context) ([]SavedTrackItem, error
 		}
 
 		offset += limit
-
-		// small delay to avoid hitting rate limits aggressively
-		time.Sleep(100 * time.Millisecond)
 	}
 
 	log.Printf("Fetched %d total saved tracks.", len(allTracks))
 	return allTracks, nil
 }
 
+// GetCurrentUserPlaylists retrieves the playlists owned or followed by the user.
+func (c *client) GetCurrentUserPlaylists(ctx context.Context, limit, offset int) (*Playlists, error) {
+	var playlists Playlists
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me", "playlists"},
+		query: map[string][]string{
+			"limit":  {fmt.Sprintf("%d", limit)},
+			"offset": {fmt.Sprintf("%d", offset)},
+		},
+	}, &playlists); err != nil {
+		return nil, fmt.Errorf("failed to get playlists: %w", err)
+	}
+
+	return &playlists, nil
+}
+
+// CreatePlaylist creates a new playlist owned by the given user.
+func (c *client) CreatePlaylist(ctx context.Context, userID, name, description string, public bool) (*Playlist, error) {
+	var playlist Playlist
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Post,
+		path:   []string{"v1", "users", userID, "playlists"},
+		body: map[string]interface{}{
+			"name":        name,
+			"description": description,
+			"public":      public,
+		},
+	}, &playlist); err != nil {
+		return nil, fmt.Errorf("failed to create playlist: %w", err)
+	}
+
+	return &playlist, nil
+}
+
+// GetPlaylistItems retrieves a page of tracks from a playlist, oldest first.
+func (c *client) GetPlaylistItems(ctx context.Context, playlistID string, limit, offset int) (*PlaylistItems, error) {
+	var items PlaylistItems
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "playlists", playlistID, "tracks"},
+		query: map[string][]string{
+			"limit":  {fmt.Sprintf("%d", limit)},
+			"offset": {fmt.Sprintf("%d", offset)},
+		},
+	}, &items); err != nil {
+		return nil, fmt.Errorf("failed to get playlist items: %w", err)
+	}
+
+	return &items, nil
+}
+
+// AddItemsToPlaylist appends tracks to the end of a playlist.
+func (c *client) AddItemsToPlaylist(ctx context.Context, playlistID string, uris []string) error {
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Post,
+		path:   []string{"v1", "playlists", playlistID, "tracks"},
+		body: map[string]interface{}{
+			"uris": uris,
+		},
+	}, nil); err != nil {
+		return fmt.Errorf("failed to add items to playlist: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveItemsFromPlaylist removes every occurrence of the given tracks from a playlist.
+func (c *client) RemoveItemsFromPlaylist(ctx context.Context, playlistID string, uris []string) error {
+	tracks := make([]map[string]string, len(uris))
+	for i, uri := range uris {
+		tracks[i] = map[string]string{"uri": uri}
+	}
+
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Delete,
+		path:   []string{"v1", "playlists", playlistID, "tracks"},
+		body: map[string]interface{}{
+			"tracks": tracks,
+		},
+	}, nil); err != nil {
+		return fmt.Errorf("failed to remove items from playlist: %w", err)
+	}
+
+	return nil
+}
+
+// GetTopArtists retrieves the user's most listened to artists over the given time range.
+func (c *client) GetTopArtists(ctx context.Context, timeRange TimeRange, limit int) (*TopArtists, error) {
+	var artists TopArtists
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me", "top", "artists"},
+		query: map[string][]string{
+			"time_range": {string(timeRange)},
+			"limit":      {fmt.Sprintf("%d", limit)},
+		},
+	}, &artists); err != nil {
+		return nil, fmt.Errorf("failed to get top artists: %w", err)
+	}
+
+	return &artists, nil
+}
+
+// GetTopTracks retrieves the user's most listened to tracks over the given time range.
+func (c *client) GetTopTracks(ctx context.Context, timeRange TimeRange, limit int) (*TopTracks, error) {
+	var tracks TopTracks
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me", "top", "tracks"},
+		query: map[string][]string{
+			"time_range": {string(timeRange)},
+			"limit":      {fmt.Sprintf("%d", limit)},
+		},
+	}, &tracks); err != nil {
+		return nil, fmt.Errorf("failed to get top tracks: %w", err)
+	}
+
+	return &tracks, nil
+}
+
+// GetRecentlyPlayed retrieves the user's most recently played tracks.
+func (c *client) GetRecentlyPlayed(ctx context.Context, limit int) (*RecentlyPlayed, error) {
+	var recent RecentlyPlayed
+	if _, err := c.do(ctx, apiRequest{
+		method: request.Get,
+		path:   []string{"v1", "me", "player", "recently-played"},
+		query: map[string][]string{
+			"limit": {fmt.Sprintf("%d", limit)},
+		},
+	}, &recent); err != nil {
+		return nil, fmt.Errorf("failed to get recently played: %w", err)
+	}
+
+	return &recent, nil
+}
+
+// GetArtists retrieves full artist details, including genres, for the given IDs.
+// Lookups are batched and cached, and artists are returned in the order requested.
+func (c *client) GetArtists(ctx context.Context, ids []string) ([]Artist, error) {
+	var missing []string
+	c.artistMu.RLock()
+	for _, id := range ids {
+		if _, ok := c.artistCache[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	c.artistMu.RUnlock()
+
+	for start := 0; start < len(missing); start += maxArtistsPerRequest {
+		end := start + maxArtistsPerRequest
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		var artists Artists
+		if _, err := c.do(ctx, apiRequest{
+			method: request.Get,
+			path:   []string{"v1", "artists"},
+			query: map[string][]string{
+				"ids": {strings.Join(missing[start:end], ",")},
+			},
+		}, &artists); err != nil {
+			return nil, fmt.Errorf("failed to get artists: %w", err)
+		}
+
+		c.artistMu.Lock()
+		for _, artist := range artists.Artists {
+			// Unknown IDs come back as null entries
+			if artist.ID != "" {
+				c.artistCache[artist.ID] = artist
+			}
+		}
+		c.artistMu.Unlock()
+	}
+
+	c.artistMu.RLock()
+	defer c.artistMu.RUnlock()
+
+	result := make([]Artist, 0, len(ids))
+	for _, id := range ids {
+		if artist, ok := c.artistCache[id]; ok {
+			result = append(result, artist)
+		}
+	}
+
+	return result, nil
+}
+
 // SaveOpenAICreds saves the OpenAI API key to the OS keychain.
 func SaveOpenAICreds(ctx context.Context, apiKey string) error {
 	if err := creds.SaveOpenAIKey(apiKey); err != nil {
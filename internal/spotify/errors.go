@@ -1,8 +1,115 @@
 package spotify
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
 
 var (
 	// ErrNotAuthenticated is returned when trying to access Spotify API without authentication
 	ErrNotAuthenticated = errors.New("not authenticated with Spotify")
+	// ErrPremiumRequired is returned for playback controls, which Spotify only allows for Premium accounts
+	ErrPremiumRequired = errors.New("spotify premium is required")
+	// ErrNoActiveDevice is returned when a playback command has no device to act on
+	ErrNoActiveDevice = errors.New("no active Spotify device")
+	// ErrForbidden is returned when the token lacks a scope or the user can't access the resource
+	ErrForbidden = errors.New("forbidden by Spotify")
+	// ErrNotFound is returned when the requested resource doesn't exist
+	ErrNotFound = errors.New("not found on Spotify")
+	// ErrRateLimited is returned when Spotify keeps rate limiting after the retries are used up
+	ErrRateLimited = errors.New("rate limited by Spotify")
+)
+
+// Reasons Spotify gives in player error bodies
+const (
+	reasonPremiumRequired = "PREMIUM_REQUIRED"
+	reasonNoActiveDevice  = "NO_ACTIVE_DEVICE"
+)
+
+// APIError is an error response from the Spotify Web API. It unwraps to one of the Err values above
+// where there's a match, so callers can check for them with errors.Is.
+type APIError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("spotify returned %d: %s (%s)", e.Status, e.Message, e.Reason)
+	}
+	return fmt.Sprintf("spotify returned %d: %s", e.Status, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.Reason == reasonPremiumRequired:
+		return ErrPremiumRequired
+	case e.Reason == reasonNoActiveDevice:
+		return ErrNoActiveDevice
+	case e.Status == http.StatusUnauthorized:
+		return ErrNotAuthenticated
+	case e.Status == http.StatusForbidden:
+		return ErrForbidden
+	case e.Status == http.StatusNotFound:
+		return ErrNotFound
+	case e.Status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// newAPIError builds an APIError from a Spotify error body, of the form
+// {"error": {"status": 403, "message": "...", "reason": "PREMIUM_REQUIRED"}}
+func newAPIError(status int, body []byte, fallback error) *APIError {
+	var parsed struct {
+		Error APIError `json:"error"`
+	}
+	apiErr := &APIError{Status: status}
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error.Message != "" {
+		apiErr.Message = parsed.Error.Message
+		apiErr.Reason = parsed.Error.Reason
+	} else if fallback != nil {
+		apiErr.Message = fallback.Error()
+	} else {
+		apiErr.Message = http.StatusText(status)
+	}
+	return apiErr
+}
+
+// AuthErrorCode identifies why the OAuth flow failed, so the frontend can react to each case
+type AuthErrorCode string
+
+const (
+	AuthTimeout         AuthErrorCode = "timeout"
+	AuthDenied          AuthErrorCode = "denied"
+	AuthStateMismatch   AuthErrorCode = "state_mismatch"
+	AuthPortUnavailable AuthErrorCode = "port_unavailable"
+	AuthBrowserFailed   AuthErrorCode = "browser_failed"
+	AuthExchangeFailed  AuthErrorCode = "exchange_failed"
+	AuthCancelled       AuthErrorCode = "cancelled"
 )
+
+// AuthError is returned by the OAuth flow when the user couldn't be authenticated
+type AuthError struct {
+	Code    AuthErrorCode `json:"code"`
+	Message string        `json:"message"`
+	err     error
+}
+
+func (e *AuthError) Error() string {
+	return e.Message
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.err
+}
+
+func newAuthError(code AuthErrorCode, message string, err error) *AuthError {
+	if err != nil {
+		message = message + ": " + err.Error()
+	}
+	return &AuthError{Code: code, Message: message, err: err}
+}
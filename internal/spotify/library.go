@@ -0,0 +1,302 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LibrarySuffix is appended to the user ID to name the saved track snapshot file
+const LibrarySuffix = "_spotify_library.json"
+
+// savedTracksPageSize is the most saved tracks Spotify returns per page
+const savedTracksPageSize = 50
+
+// LibrarySnapshot is a local copy of the user's saved tracks, newest first
+type LibrarySnapshot struct {
+	Tracks   []SavedTrackItem `json:"tracks"`
+	SyncedAt int64            `json:"synced_at"`
+}
+
+// Library keeps a snapshot of the user's saved tracks on disk, so building a baseline doesn't
+// page through the whole Spotify library every time
+type Library struct {
+	data     LibrarySnapshot
+	mu       sync.RWMutex
+	syncMu   sync.Mutex // Serializes syncs, so readers aren't blocked on the network
+	filePath string
+}
+
+// NewLibrary creates a library backed by the given file, loading any existing snapshot
+func NewLibrary(filePath string) *Library {
+	l := &Library{
+		filePath: filePath,
+		data: LibrarySnapshot{
+			Tracks: []SavedTrackItem{},
+		},
+	}
+
+	if err := l.load(); err != nil {
+		log.Printf("WARNING: Failed to load Spotify library snapshot: %v", err)
+	}
+
+	return l
+}
+
+// Tracks returns the snapshot of saved tracks, newest first
+func (l *Library) Tracks() []SavedTrackItem {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	result := make([]SavedTrackItem, len(l.data.Tracks))
+	copy(result, l.data.Tracks)
+	return result
+}
+
+// SyncedAt returns when the snapshot was last synced, or the zero time if it never has been
+func (l *Library) SyncedAt() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.data.SyncedAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(l.data.SyncedAt, 0)
+}
+
+// Sync fetches tracks saved since the last sync, paging from the newest until it reaches a track
+// already in the snapshot. Tracks saved while paging shift the pages along, so one that turns up twice
+// is only added once. Removals can't be seen that way, so if the counts disagree afterwards the whole
+// library is fetched again. Returns the number of tracks added.
+func (l *Library) Sync(ctx context.Context, client Client) (int, error) {
+	l.syncMu.Lock()
+	defer l.syncMu.Unlock()
+
+	known := l.Tracks()
+	if len(known) == 0 {
+		return l.resync(ctx, client)
+	}
+
+	ids := make(map[string]bool, len(known))
+	for _, item := range known {
+		if item.Track != nil {
+			ids[item.Track.ID] = true
+		}
+	}
+
+	var added []SavedTrackItem
+	seen := make(map[string]bool)
+	total := 0
+	for offset := 0; ; offset += savedTracksPageSize {
+		page, err := client.GetSavedTracks(ctx, savedTracksPageSize, offset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get saved tracks page (offset %d): %w", offset, err)
+		}
+		total = page.Total
+
+		reachedKnown := false
+		for _, item := range page.Items {
+			if item.Track == nil {
+				continue
+			}
+			if ids[item.Track.ID] {
+				reachedKnown = true
+				break
+			}
+			if seen[item.Track.ID] {
+				continue
+			}
+			seen[item.Track.ID] = true
+			added = append(added, item)
+		}
+
+		if reachedKnown || len(page.Items) < savedTracksPageSize || offset+len(page.Items) >= total {
+			break
+		}
+	}
+
+	merged := append(added, known...)
+	if len(merged) != total {
+		log.Printf("Spotify library has %d tracks but the snapshot would have %d, resyncing", total, len(merged))
+		return l.resync(ctx, client)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.data.Tracks = merged
+	l.data.SyncedAt = time.Now().Unix()
+	if len(added) > 0 {
+		log.Printf("Added %d newly saved tracks to the Spotify library snapshot", len(added))
+	}
+
+	return len(added), l.save()
+}
+
+// Resync replaces the snapshot with a full fetch of the user's saved tracks, returning how many there are
+func (l *Library) Resync(ctx context.Context, client Client) (int, error) {
+	l.syncMu.Lock()
+	defer l.syncMu.Unlock()
+
+	return l.resync(ctx, client)
+}
+
+// resync does the work of Resync; callers must hold syncMu
+func (l *Library) resync(ctx context.Context, client Client) (int, error) {
+	tracks, err := client.GetAllLikedTracks(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.data.Tracks = tracks
+	l.data.SyncedAt = time.Now().Unix()
+
+	return len(tracks), l.save()
+}
+
+// Remove drops a track from the snapshot, for when it's removed from the library within the app
+func (l *Library) Remove(trackID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	kept := make([]SavedTrackItem, 0, len(l.data.Tracks))
+	for _, item := range l.data.Tracks {
+		if item.Track == nil || item.Track.ID != trackID {
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == len(l.data.Tracks) {
+		return nil
+	}
+
+	l.data.Tracks = kept
+	return l.save()
+}
+
+// Clear empties the snapshot, for when the Spotify account is disconnected
+func (l *Library) Clear() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.data = LibrarySnapshot{Tracks: []SavedTrackItem{}}
+	return l.save()
+}
+
+// Contains reports whether a track is in the snapshot
+func (l *Library) Contains(trackID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, item := range l.data.Tracks {
+		if item.Track != nil && item.Track.ID == trackID {
+			return true
+		}
+	}
+	return false
+}
+
+// Search finds saved tracks whose title, artist or album contain every word of the query, ranking
+// title matches first and otherwise keeping the newest first
+func (l *Library) Search(query string, limit int) []SavedTrackItem {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return []SavedTrackItem{}
+	}
+
+	type match struct {
+		item  SavedTrackItem
+		score int
+		index int
+	}
+
+	l.mu.RLock()
+	var matches []match
+	for i, item := range l.data.Tracks {
+		if item.Track == nil {
+			continue
+		}
+
+		title := strings.ToLower(item.Track.Name)
+		var artists []string
+		for _, artist := range item.Track.Artists {
+			artists = append(artists, artist.Name)
+		}
+		text := strings.ToLower(strings.Join(append(artists, item.Track.Name, item.Track.Album.Name), " "))
+
+		score := 0
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(text, term) {
+				matched = false
+				break
+			}
+			if strings.Contains(title, term) {
+				score++
+			}
+		}
+		if matched {
+			matches = append(matches, match{item: item, score: score, index: i})
+		}
+	}
+	l.mu.RUnlock()
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].index < matches[j].index
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	result := make([]SavedTrackItem, len(matches))
+	for i, m := range matches {
+		result[i] = m.item
+	}
+	return result
+}
+
+// load loads the snapshot from disk, leaving it empty if there isn't one yet
+func (l *Library) load() error {
+	data, err := os.ReadFile(l.filePath)
+	if os.IsNotExist(err) {
+		log.Printf("No Spotify library snapshot exists at %s, it will be created on the first sync", l.filePath)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read library snapshot: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &l.data); err != nil {
+		return fmt.Errorf("failed to unmarshal library snapshot: %w", err)
+	}
+
+	log.Printf("Loaded Spotify library snapshot with %d tracks", len(l.data.Tracks))
+
+	return nil
+}
+
+// save writes the snapshot to disk; callers must hold mu
+func (l *Library) save() error {
+	data, err := json.Marshal(l.data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal library snapshot: %w", err)
+	}
+
+	if err := os.WriteFile(l.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write library snapshot: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// fakeLibraryClient serves saved tracks from pages given up front, newest first. Only the calls a
+// Library makes are implemented.
+type fakeLibraryClient struct {
+	Client
+	pages   [][]SavedTrackItem // What each GetSavedTracks call returns in turn
+	all     []SavedTrackItem   // What GetAllLikedTracks returns
+	total   int
+	calls   int
+	resyncs int
+}
+
+func (f *fakeLibraryClient) GetSavedTracks(_ context.Context, limit, offset int) (*SavedTracks, error) {
+	if f.calls >= len(f.pages) {
+		return nil, fmt.Errorf("unexpected page at offset %d", offset)
+	}
+	page := f.pages[f.calls]
+	f.calls++
+	return &SavedTracks{Items: page, Total: f.total, Limit: limit, Offset: offset}, nil
+}
+
+func (f *fakeLibraryClient) GetAllLikedTracks(context.Context) ([]SavedTrackItem, error) {
+	f.resyncs++
+	return f.all, nil
+}
+
+func savedTrack(id, name, artist, album string) SavedTrackItem {
+	return SavedTrackItem{Track: &Track{ID: id, Name: name, Artists: []Artist{{Name: artist}}, Album: Album{Name: album}}}
+}
+
+// savedTracks makes tracks with the given IDs, in order
+func savedTracks(ids ...string) []SavedTrackItem {
+	items := make([]SavedTrackItem, len(ids))
+	for i, id := range ids {
+		items[i] = savedTrack(id, "Track "+id, "Artist", "Album")
+	}
+	return items
+}
+
+// trackIDs returns the IDs of the tracks, in order
+func trackIDs(items []SavedTrackItem) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.Track.ID
+	}
+	return ids
+}
+
+func TestLibrarySync(t *testing.T) {
+	// A full page of new tracks, for paging past the first
+	var fullPage []string
+	for i := 0; i < savedTracksPageSize; i++ {
+		fullPage = append(fullPage, fmt.Sprintf("new%d", i))
+	}
+
+	tests := []struct {
+		name        string
+		known       []string
+		client      *fakeLibraryClient
+		wantAdded   int
+		wantTracks  []string
+		wantResyncs int
+	}{
+		{
+			name:       "nothing new",
+			known:      []string{"a", "b"},
+			client:     &fakeLibraryClient{pages: [][]SavedTrackItem{savedTracks("a", "b")}, total: 2},
+			wantTracks: []string{"a", "b"},
+		},
+		{
+			name:       "new tracks are merged in front",
+			known:      []string{"a", "b"},
+			client:     &fakeLibraryClient{pages: [][]SavedTrackItem{savedTracks("d", "c", "a", "b")}, total: 4},
+			wantAdded:  2,
+			wantTracks: []string{"d", "c", "a", "b"},
+		},
+		{
+			name:  "pages until a known track",
+			known: []string{"a"},
+			client: &fakeLibraryClient{
+				pages: [][]SavedTrackItem{savedTracks(fullPage...), savedTracks("x", "a")},
+				total: savedTracksPageSize + 2,
+			},
+			wantAdded:  savedTracksPageSize + 1,
+			wantTracks: append(append([]string{}, fullPage...), "x", "a"),
+		},
+		{
+			name:  "track repeated across shifted pages is added once",
+			known: []string{"a"},
+			client: &fakeLibraryClient{
+				pages: [][]SavedTrackItem{savedTracks(fullPage...), savedTracks(fullPage[savedTracksPageSize-1], "a")},
+				total: savedTracksPageSize + 1,
+			},
+			wantAdded:  savedTracksPageSize,
+			wantTracks: append(append([]string{}, fullPage...), "a"),
+		},
+		{
+			name:  "removals trigger a resync",
+			known: []string{"a", "b", "c"},
+			client: &fakeLibraryClient{
+				pages: [][]SavedTrackItem{savedTracks("d", "a")},
+				all:   savedTracks("d", "a", "c"),
+				total: 3,
+			},
+			wantAdded:   3,
+			wantTracks:  []string{"d", "a", "c"},
+			wantResyncs: 1,
+		},
+		{
+			name:        "empty snapshot is fetched in full",
+			client:      &fakeLibraryClient{all: savedTracks("a", "b")},
+			wantAdded:   2,
+			wantTracks:  []string{"a", "b"},
+			wantResyncs: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "library.json")
+			library := NewLibrary(path)
+			library.data.Tracks = savedTracks(tt.known...)
+
+			added, err := library.Sync(context.Background(), tt.client)
+			if err != nil {
+				t.Fatalf("Sync() failed: %v", err)
+			}
+
+			if added != tt.wantAdded {
+				t.Errorf("Sync() added %d, want %d", added, tt.wantAdded)
+			}
+			if got := trackIDs(library.Tracks()); !slices.Equal(got, tt.wantTracks) {
+				t.Errorf("Tracks() = %v, want %v", got, tt.wantTracks)
+			}
+			if tt.client.resyncs != tt.wantResyncs {
+				t.Errorf("resynced %d times, want %d", tt.client.resyncs, tt.wantResyncs)
+			}
+			if library.SyncedAt().IsZero() {
+				t.Error("SyncedAt() is zero after syncing")
+			}
+
+			// The snapshot is kept on disk for the next run
+			if got := trackIDs(NewLibrary(path).Tracks()); !slices.Equal(got, tt.wantTracks) {
+				t.Errorf("Tracks() after reloading = %v, want %v", got, tt.wantTracks)
+			}
+		})
+	}
+}
+
+func TestLibrarySearch(t *testing.T) {
+	library := NewLibrary(filepath.Join(t.TempDir(), "library.json"))
+	library.data.Tracks = []SavedTrackItem{
+		savedTrack("1", "Alison", "Slowdive", "Souvlaki"),
+		savedTrack("2", "When the Sun Hits", "Slowdive", "Souvlaki"),
+		{},
+		savedTrack("3", "Souvlaki Space Station", "Slowdive", "Souvlaki"),
+		savedTrack("4", "Space Song", "Beach House", "Depression Cherry"),
+	}
+
+	tests := []struct {
+		query string
+		limit int
+		want  []string
+	}{
+		{"slowdive", 0, []string{"1", "2", "3"}},
+		{"SLOWDIVE souvlaki", 0, []string{"3", "1", "2"}},
+		{"space", 0, []string{"3", "4"}},
+		{"slowdive", 2, []string{"1", "2"}},
+		{"beach cherry", 0, []string{"4"}},
+		{"slowdive cherry", 0, []string{}},
+		{"  ", 0, []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := trackIDs(library.Search(tt.query, tt.limit)); !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q, %d) = %v, want %v", tt.query, tt.limit, got, tt.want)
+		}
+	}
+}
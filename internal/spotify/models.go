@@ -9,16 +9,28 @@ type Image struct {
 
 // Artist represents a Spotify artist
 type Artist struct {
-	ID     string   `json:"id"`
-	Name   string   `json:"name"`
-	Genres []string `json:"genres"`
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Genres     []string `json:"genres"`
+	Images     []Image  `json:"images,omitempty"`
+	Popularity int      `json:"popularity,omitempty"`
+	URI        string   `json:"uri,omitempty"`
+}
+
+// Artists represents the response from a batched artist lookup
+type Artists struct {
+	Artists []Artist `json:"artists"`
 }
 
 // Album represents a Spotify album
 type Album struct {
-	ID     string  `json:"id"`
-	Name   string  `json:"name"`
-	Images []Image `json:"images"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Images      []Image  `json:"images"`
+	Artists     []Artist `json:"artists,omitempty"`
+	ReleaseDate string   `json:"release_date,omitempty"`
+	TotalTracks int      `json:"total_tracks,omitempty"`
+	URI         string   `json:"uri,omitempty"`
 }
 
 // UserProfile represents a Spotify user's profile data.
@@ -39,6 +51,7 @@ type Track struct {
 	Album      Album    `json:"album"`
 	PreviewUrl string   `json:"preview_url"`
 	URI        string   `json:"uri"`
+	Popularity int      `json:"popularity,omitempty"`
 }
 
 // SimpleTrack is a simplified track representation for the frontend
@@ -52,6 +65,7 @@ type SimpleTrack struct {
 	AlbumArtUrl string `json:"albumArtUrl"`
 	PreviewUrl  string `json:"previewUrl"`
 	URI         string `json:"uri"`
+	Popularity  int    `json:"popularity,omitempty"`
 }
 
 // SavedTrackItem represents a single saved track with metadata
@@ -60,11 +74,39 @@ type SavedTrackItem struct {
 	AddedAt string `json:"added_at"`
 }
 
+// SimpleAlbum is a simplified album representation for the frontend
+type SimpleAlbum struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Artist      string `json:"artist"`
+	ArtistID    string `json:"artistId"`
+	ReleaseDate string `json:"releaseDate"`
+	TotalTracks int    `json:"totalTracks"`
+	AlbumArtUrl string `json:"albumArtUrl"`
+	URI         string `json:"uri"`
+}
+
+// SimpleArtist is a simplified artist representation for the frontend
+type SimpleArtist struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Genres     []string `json:"genres"`
+	ImageUrl   string   `json:"imageUrl"`
+	Popularity int      `json:"popularity"`
+	URI        string   `json:"uri"`
+}
+
 // SearchResults represents search results from Spotify
 type SearchResults struct {
 	Tracks struct {
 		Items []*Track `json:"items"`
 	} `json:"tracks"`
+	Albums struct {
+		Items []*Album `json:"items"`
+	} `json:"albums"`
+	Artists struct {
+		Items []*Artist `json:"items"`
+	} `json:"artists"`
 }
 
 // SavedTracks represents the response from getting user's saved tracks
@@ -77,6 +119,115 @@ type SavedTracks struct {
 	Previous string           `json:"previous"`
 }
 
+// Playlist represents a Spotify playlist
+type Playlist struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Public      bool    `json:"public"`
+	URI         string  `json:"uri"`
+	SnapshotID  string  `json:"snapshot_id"`
+	Images      []Image `json:"images"`
+	Owner       struct {
+		ID string `json:"id"`
+	} `json:"owner"`
+	Tracks struct {
+		Total int `json:"total"`
+	} `json:"tracks"`
+}
+
+// Playlists represents a page of the user's playlists
+type Playlists struct {
+	Items  []Playlist `json:"items"`
+	Total  int        `json:"total"`
+	Limit  int        `json:"limit"`
+	Offset int        `json:"offset"`
+	Next   string     `json:"next"`
+}
+
+// PlaylistItem represents a single track in a playlist with metadata
+type PlaylistItem struct {
+	Track   *Track `json:"track"`
+	AddedAt string `json:"added_at"`
+}
+
+// PlaylistItems represents a page of tracks in a playlist
+type PlaylistItems struct {
+	Items  []PlaylistItem `json:"items"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	Next   string         `json:"next"`
+}
+
+// TimeRange is the window Spotify computes a user's top items over
+type TimeRange string
+
+const (
+	ShortTerm  TimeRange = "short_term"  // Roughly the last 4 weeks
+	MediumTerm TimeRange = "medium_term" // Roughly the last 6 months
+	LongTerm   TimeRange = "long_term"   // Roughly the last year
+)
+
+// TopArtists represents a page of the user's most listened to artists
+type TopArtists struct {
+	Items  []Artist `json:"items"`
+	Total  int      `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+	Next   string   `json:"next"`
+}
+
+// TopTracks represents a page of the user's most listened to tracks
+type TopTracks struct {
+	Items  []*Track `json:"items"`
+	Total  int      `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+	Next   string   `json:"next"`
+}
+
+// PlayHistoryItem represents a single play of a track
+type PlayHistoryItem struct {
+	Track    *Track `json:"track"`
+	PlayedAt string `json:"played_at"`
+}
+
+// RecentlyPlayed represents the user's most recent plays, newest first
+type RecentlyPlayed struct {
+	Items []PlayHistoryItem `json:"items"`
+	Limit int               `json:"limit"`
+	Next  string            `json:"next"`
+}
+
+// Device represents a Spotify Connect device the user can play on
+type Device struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	IsActive         bool   `json:"is_active"`
+	IsPrivateSession bool   `json:"is_private_session"`
+	IsRestricted     bool   `json:"is_restricted"`
+	VolumePercent    int    `json:"volume_percent"`
+}
+
+// Devices represents the response from listing the user's devices
+type Devices struct {
+	Devices []Device `json:"devices"`
+}
+
+// PlaybackState represents what the user is currently playing, and where
+type PlaybackState struct {
+	Device               Device `json:"device"`
+	IsPlaying            bool   `json:"is_playing"`
+	ShuffleState         bool   `json:"shuffle_state"`
+	RepeatState          string `json:"repeat_state"`
+	ProgressMs           int    `json:"progress_ms"`
+	Timestamp            int64  `json:"timestamp"`
+	CurrentlyPlayingType string `json:"currently_playing_type"`
+	Item                 *Track `json:"item"`
+}
+
 type AuthResponse struct {
 	AccessToken  string `json:"access_token"`
 	TokenType    string `json:"token_type"`
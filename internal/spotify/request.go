@@ -0,0 +1,173 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"interestnaut/internal/endpoints"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	request "github.com/catlee993/go-request"
+)
+
+const (
+	// maxRateLimitRetries caps how many 429s a single call waits out before giving up
+	maxRateLimitRetries = 3
+	// maxRetryAfter bounds a single wait, in case Spotify asks for longer than anyone would sit through
+	maxRetryAfter     = 30 * time.Second
+	defaultRetryAfter = time.Second
+)
+
+// apiRequest describes a single call to the Spotify Web API
+type apiRequest struct {
+	method request.Method
+	path   []string
+	query  map[string][]string
+	body   interface{} // Sent as JSON when set
+}
+
+// do sends a request to the Spotify Web API, decoding a successful response into out when it's non-nil.
+// An expired token is refreshed and the request retried once, rate limits are waited out according to
+// Retry-After, and error responses are mapped to an *APIError. The status is returned so callers can
+// tell an empty 204 apart from a decoded body.
+func (c *client) do(ctx context.Context, req apiRequest, out interface{}) (int, error) {
+	var bodyBytes []byte
+	if req.body != nil {
+		var err error
+		if bodyBytes, err = json.Marshal(req.body); err != nil {
+			return 0, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	endpoint := endpoints.Get(endpoints.SpotifyAPI)
+	refreshed := false
+	rateLimited := 0
+	for {
+		token, err := GetValidToken(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		headers := map[string][]string{
+			"Authorization": {"Bearer " + token},
+		}
+		if bodyBytes != nil {
+			headers["Content-Type"] = []string{"application/json"}
+		}
+
+		requester, err := request.NewRequester(
+			request.WithScheme(endpoint.RequestScheme()),
+			request.WithMethod(req.method),
+			request.WithHost(endpoint.Address()),
+			request.WithPath(req.path...),
+			request.WithQueryArgs(req.query),
+			request.WithBody(bodyBytes),
+			request.WithHeaders(headers),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create requester: %w", err)
+		}
+
+		resp, rErr := requester.Make(ctx, nil)
+		var status int
+		var apiErr error
+		var header http.Header
+		if resp != nil {
+			status, apiErr = readResponse(resp, rErr, out)
+			header = resp.Header
+		} else {
+			status, apiErr = readError(rErr)
+			if status == 0 {
+				return 0, apiErr
+			}
+		}
+
+		switch {
+		case status == http.StatusUnauthorized && !refreshed:
+			// The token can be revoked or expire early, so get a fresh one and try again
+			log.Printf("Spotify returned 401 for %s, refreshing token", strings.Join(req.path, "/"))
+			refreshed = true
+			invalidateAccessToken(token)
+			continue
+		case status == http.StatusTooManyRequests && rateLimited < maxRateLimitRetries:
+			rateLimited++
+			wait := retryAfter(header.Get("Retry-After"))
+			log.Printf("Spotify rate limited %s, retrying in %s", strings.Join(req.path, "/"), wait)
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		return status, apiErr
+	}
+}
+
+// readResponse closes the response, decoding it into out on success or into an *APIError otherwise
+func readResponse(resp *http.Response, makeErr error, out interface{}) (int, error) {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, newAPIError(resp.StatusCode, body, makeErr)
+	}
+	if makeErr != nil {
+		return resp.StatusCode, fmt.Errorf("request failed: %w", makeErr)
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent && len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// errorStatus finds the HTTP status go-request puts in an error message, e.g. "status 429: ..." or
+// "unexpected status code 502", and not other numbers such as the port in a dial error
+var errorStatus = regexp.MustCompile(`(?i)\bstatus(?: code)?:? (\d{3})\b`)
+
+// readError handles a request that failed without a response. go-request reports error statuses that way as
+// well as network failures, with the status only in the message, so it's recovered from there where it can be
+// and mapped to an *APIError. Otherwise the status is 0 and the error is returned as is.
+func readError(makeErr error) (int, error) {
+	if makeErr == nil {
+		return 0, fmt.Errorf("request failed: no response")
+	}
+
+	match := errorStatus.FindStringSubmatch(makeErr.Error())
+	if match == nil {
+		return 0, fmt.Errorf("request failed: %w", makeErr)
+	}
+
+	status, _ := strconv.Atoi(match[1])
+	if status < http.StatusBadRequest || http.StatusText(status) == "" {
+		return 0, fmt.Errorf("request failed: %w", makeErr)
+	}
+	return status, newAPIError(status, nil, makeErr)
+}
+
+// retryAfter parses a Retry-After header given in seconds, clamped to something reasonable
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+
+	wait := time.Duration(seconds) * time.Second
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait
+}
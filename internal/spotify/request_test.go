@@ -0,0 +1,229 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"interestnaut/internal/creds"
+	"interestnaut/internal/endpoints"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	request "github.com/catlee993/go-request"
+	"github.com/zalando/go-keyring"
+)
+
+// testServer stands in for both the Web API and the accounts service, via the endpoint overrides
+var testServer struct {
+	mu      sync.Mutex
+	api     http.HandlerFunc
+	refresh int // How many times the token was refreshed
+}
+
+func TestMain(m *testing.M) {
+	keyring.MockInit()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testServer.mu.Lock()
+		defer testServer.mu.Unlock()
+
+		if r.URL.Path == "/api/token" {
+			testServer.refresh++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"access_token": "fresh-%d", "expires_in": 3600, "scope": %q}`, testServer.refresh, scope)
+			return
+		}
+		testServer.api(w, r)
+	}))
+
+	dir, err := os.MkdirTemp("", "spotify-test")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv(endpoints.FileEnv, filepath.Join(dir, "endpoints.json"))
+	os.Setenv("INTERESTNAUT_SPOTIFY_API_URL", server.URL)
+	os.Setenv("INTERESTNAUT_SPOTIFY_ACCOUNTS_URL", server.URL)
+
+	code := m.Run()
+
+	server.Close()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// serveAPI answers Web API requests with the given responses in turn, repeating the last one, and returns
+// the tokens each request was made with
+func serveAPI(t *testing.T, responses ...func(w http.ResponseWriter)) *[]string {
+	t.Helper()
+
+	if err := creds.SaveSpotifyToken("refresh-token"); err != nil {
+		t.Fatalf("failed to save refresh token: %v", err)
+	}
+	tokenMutex.Lock()
+	accessToken, tokenExpiry, grantedScope = "stale", time.Now().Add(time.Hour), scope
+	tokenMutex.Unlock()
+
+	var tokens []string
+	testServer.mu.Lock()
+	defer testServer.mu.Unlock()
+	testServer.refresh = 0
+	testServer.api = func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		responses[min(len(tokens), len(responses))-1](w)
+	}
+	return &tokens
+}
+
+func respond(status int, body string, headers ...string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for i := 0; i+1 < len(headers); i += 2 {
+			w.Header().Set(headers[i], headers[i+1])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+func TestDo(t *testing.T) {
+	ok := respond(http.StatusOK, `{"id": "user"}`)
+
+	tests := []struct {
+		name        string
+		responses   []func(w http.ResponseWriter)
+		wantStatus  int
+		wantErr     error
+		wantTokens  []string
+		wantRefresh int
+	}{
+		{
+			name:       "success",
+			responses:  []func(w http.ResponseWriter){ok},
+			wantStatus: http.StatusOK,
+			wantTokens: []string{"Bearer stale"},
+		},
+		{
+			name:        "expired token is refreshed once",
+			responses:   []func(w http.ResponseWriter){respond(http.StatusUnauthorized, `{"error": {"status": 401, "message": "The access token expired"}}`), ok},
+			wantStatus:  http.StatusOK,
+			wantTokens:  []string{"Bearer stale", "Bearer fresh-1"},
+			wantRefresh: 1,
+		},
+		{
+			name:        "rejected refreshed token",
+			responses:   []func(w http.ResponseWriter){respond(http.StatusUnauthorized, `{"error": {"status": 401, "message": "Invalid access token"}}`)},
+			wantStatus:  http.StatusUnauthorized,
+			wantErr:     ErrNotAuthenticated,
+			wantTokens:  []string{"Bearer stale", "Bearer fresh-1"},
+			wantRefresh: 1,
+		},
+		{
+			name:       "rate limit is waited out",
+			responses:  []func(w http.ResponseWriter){respond(http.StatusTooManyRequests, "", "Retry-After", "1"), ok},
+			wantStatus: http.StatusOK,
+			wantTokens: []string{"Bearer stale", "Bearer stale"},
+		},
+		{
+			name:       "rate limit outlasts the retries",
+			responses:  []func(w http.ResponseWriter){respond(http.StatusTooManyRequests, "", "Retry-After", "1")},
+			wantStatus: http.StatusTooManyRequests,
+			wantErr:    ErrRateLimited,
+			wantTokens: []string{"Bearer stale", "Bearer stale", "Bearer stale", "Bearer stale"},
+		},
+		{
+			name:       "server error isn't retried",
+			responses:  []func(w http.ResponseWriter){respond(http.StatusServiceUnavailable, `{"error": {"status": 503, "message": "Service unavailable"}}`)},
+			wantStatus: http.StatusServiceUnavailable,
+			wantTokens: []string{"Bearer stale"},
+		},
+		{
+			name:       "not found",
+			responses:  []func(w http.ResponseWriter){respond(http.StatusNotFound, `{"error": {"status": 404, "message": "Non existing id"}}`)},
+			wantStatus: http.StatusNotFound,
+			wantErr:    ErrNotFound,
+			wantTokens: []string{"Bearer stale"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := serveAPI(t, tt.responses...)
+			c := NewClient().(*client)
+
+			var profile UserProfile
+			status, err := c.do(context.Background(), apiRequest{method: request.Get, path: []string{"v1", "me"}}, &profile)
+
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if tt.wantStatus < http.StatusMultipleChoices {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if profile.ID != "user" {
+					t.Errorf("decoded profile ID = %q, want %q", profile.ID, "user")
+				}
+			} else {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.Status != tt.wantStatus {
+					t.Errorf("error = %v, want an *APIError with status %d", err, tt.wantStatus)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+			}
+
+			testServer.mu.Lock()
+			defer testServer.mu.Unlock()
+			if fmt.Sprint(*tokens) != fmt.Sprint(tt.wantTokens) {
+				t.Errorf("requests were made with %v, want %v", *tokens, tt.wantTokens)
+			}
+			if testServer.refresh != tt.wantRefresh {
+				t.Errorf("token refreshed %d times, want %d", testServer.refresh, tt.wantRefresh)
+			}
+		})
+	}
+}
+
+func TestReadError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantErr    error
+	}{
+		{"no error", nil, 0, nil},
+		{"unauthorized", errors.New("status 401: The access token expired"), http.StatusUnauthorized, ErrNotAuthenticated},
+		{"rate limited", errors.New("unexpected status code 429"), http.StatusTooManyRequests, ErrRateLimited},
+		{"server error", errors.New("Status Code: 502 Bad Gateway"), http.StatusBadGateway, nil},
+		{"network error", errors.New("dial tcp 127.0.0.1:4040: connect: connection refused"), 0, nil},
+		{"network error on an HTTPS port", errors.New("dial tcp 35.186.224.25:443: i/o timeout"), 0, nil},
+		{"status that isn't an error", errors.New("status 304: not modified"), 0, nil},
+		{"unknown status", errors.New("status 499: client closed request"), 0, nil},
+		{"cancelled", context.Canceled, 0, context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := readError(tt.err)
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var apiErr *APIError
+			if isAPIErr := errors.As(err, &apiErr); isAPIErr != (tt.wantStatus != 0) {
+				t.Errorf("error = %v, want an *APIError: %v", err, tt.wantStatus != 0)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 package spotify
 
-// SuggestedTrackInfo holds combined details for a suggested track to be sent to the frontend.
+// SuggestedTrackInfo holds combined details for a suggested track, album or artist to be sent to the frontend.
 type SuggestedTrackInfo struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
@@ -10,6 +10,7 @@ type SuggestedTrackInfo struct {
 	AlbumArtURL string `json:"albumArtUrl,omitempty"`
 	Reason      string `json:"reason,omitempty"`
 	URI         string `json:"uri,omitempty"`
+	Unit        string `json:"unit,omitempty"` // "track", "album" or "artist"; an album or artist URI plays as a context
 }
 
 // AuthConfig represents the Spotify OAuth configuration.
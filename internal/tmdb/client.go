@@ -5,14 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"interestnaut/internal/creds"
+	"interestnaut/internal/endpoints"
 	"sync"
 
 	request "github.com/catlee993/go-request"
 )
 
 const (
-	baseURL          = "https://api.themoviedb.org/3"
-	imageBaseURL     = "https://image.tmdb.org/t/p"
+	imagePath        = "/t/p"
 	posterSizeW500   = "w500"
 	backdropSizeW780 = "w780"
 )
@@ -63,6 +63,39 @@ type TVShow struct {
 		ID   int    `json:"id"`
 		Name string `json:"name"`
 	} `json:"created_by"`
+	NumberOfSeasons  int      `json:"number_of_seasons,omitempty"`
+	NumberOfEpisodes int      `json:"number_of_episodes,omitempty"`
+	Seasons          []Season `json:"seasons,omitempty"`
+	Status           string   `json:"status,omitempty"` // e.g. "Returning Series", "Ended"
+	InProduction     bool     `json:"in_production,omitempty"`
+	LastEpisodeToAir *Episode `json:"last_episode_to_air,omitempty"`
+	NextEpisodeToAir *Episode `json:"next_episode_to_air,omitempty"`
+}
+
+// Season struct representing a single season of a TV show from TMDB API;
+// Episodes is only populated by the season details endpoint
+type Season struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Overview     string    `json:"overview"`
+	PosterPath   string    `json:"poster_path"`
+	SeasonNumber int       `json:"season_number"`
+	AirDate      string    `json:"air_date"`
+	EpisodeCount int       `json:"episode_count"`
+	Episodes     []Episode `json:"episodes,omitempty"`
+}
+
+// Episode struct representing a single episode of a TV show from TMDB API
+type Episode struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Overview      string  `json:"overview"`
+	AirDate       string  `json:"air_date"`
+	SeasonNumber  int     `json:"season_number"`
+	EpisodeNumber int     `json:"episode_number"`
+	Runtime       int     `json:"runtime"`
+	StillPath     string  `json:"still_path"`
+	VoteAverage   float64 `json:"vote_average"`
 }
 
 type TVSearchResponse struct {
@@ -112,9 +145,9 @@ func (c *Client) SearchMovies(ctx context.Context, query string) (*SearchRespons
 	}
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.TMDB).RequestScheme()),
 		request.WithMethod(request.Get),
-		request.WithHost("api.themoviedb.org"),
+		request.WithHost(endpoints.Get(endpoints.TMDB).Address()),
 		request.WithPath("3", "search", "movie"),
 		request.WithQueryArgs(map[string][]string{
 			"api_key":       {apiKey},
@@ -145,9 +178,9 @@ func (c *Client) GetMovieDetails(ctx context.Context, movieID int) (*Movie, erro
 	}
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.TMDB).RequestScheme()),
 		request.WithMethod(request.Get),
-		request.WithHost("api.themoviedb.org"),
+		request.WithHost(endpoints.Get(endpoints.TMDB).Address()),
 		request.WithPath("3", "movie", fmt.Sprintf("%d", movieID)),
 		request.WithQueryArgs(map[string][]string{
 			"api_key": {apiKey},
@@ -170,14 +203,14 @@ func GetPosterURL(path string) string {
 	if path == "" {
 		return ""
 	}
-	return fmt.Sprintf("%s/%s%s", imageBaseURL, posterSizeW500, path)
+	return fmt.Sprintf("%s%s/%s%s", endpoints.Get(endpoints.TMDBImages).URL(), imagePath, posterSizeW500, path)
 }
 
 func GetBackdropURL(path string) string {
 	if path == "" {
 		return ""
 	}
-	return fmt.Sprintf("%s/%s%s", imageBaseURL, backdropSizeW780, path)
+	return fmt.Sprintf("%s%s/%s%s", endpoints.Get(endpoints.TMDBImages).URL(), imagePath, backdropSizeW780, path)
 }
 
 func (c *Client) SearchTVShows(ctx context.Context, query string) (*TVSearchResponse, error) {
@@ -190,9 +223,9 @@ func (c *Client) SearchTVShows(ctx context.Context, query string) (*TVSearchResp
 	}
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.TMDB).RequestScheme()),
 		request.WithMethod(request.Get),
-		request.WithHost("api.themoviedb.org"),
+		request.WithHost(endpoints.Get(endpoints.TMDB).Address()),
 		request.WithPath("3", "search", "tv"),
 		request.WithQueryArgs(map[string][]string{
 			"api_key":       {apiKey},
@@ -223,9 +256,9 @@ func (c *Client) GetTVShowDetails(ctx context.Context, showID int) (*TVShow, err
 	}
 
 	req, err := request.NewRequester(
-		request.WithScheme(request.HTTPS),
+		request.WithScheme(endpoints.Get(endpoints.TMDB).RequestScheme()),
 		request.WithMethod(request.Get),
-		request.WithHost("api.themoviedb.org"),
+		request.WithHost(endpoints.Get(endpoints.TMDB).Address()),
 		request.WithPath("3", "tv", fmt.Sprintf("%d", showID)),
 		request.WithQueryArgs(map[string][]string{
 			"api_key": {apiKey},
@@ -243,3 +276,67 @@ func (c *Client) GetTVShowDetails(ctx context.Context, showID int) (*TVShow, err
 
 	return &tvShow, nil
 }
+
+func (c *Client) GetTVSeason(ctx context.Context, showID, seasonNumber int) (*Season, error) {
+	c.mu.RLock()
+	apiKey := c.apiKey
+	c.mu.RUnlock()
+
+	if apiKey == "" {
+		return nil, ErrNoCredentials
+	}
+
+	req, err := request.NewRequester(
+		request.WithScheme(endpoints.Get(endpoints.TMDB).RequestScheme()),
+		request.WithMethod(request.Get),
+		request.WithHost(endpoints.Get(endpoints.TMDB).Address()),
+		request.WithPath("3", "tv", fmt.Sprintf("%d", showID), "season", fmt.Sprintf("%d", seasonNumber)),
+		request.WithQueryArgs(map[string][]string{
+			"api_key": {apiKey},
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var season Season
+	_, err = req.Make(ctx, &season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TV season details: %w", err)
+	}
+
+	return &season, nil
+}
+
+func (c *Client) GetTVEpisode(ctx context.Context, showID, seasonNumber, episodeNumber int) (*Episode, error) {
+	c.mu.RLock()
+	apiKey := c.apiKey
+	c.mu.RUnlock()
+
+	if apiKey == "" {
+		return nil, ErrNoCredentials
+	}
+
+	req, err := request.NewRequester(
+		request.WithScheme(endpoints.Get(endpoints.TMDB).RequestScheme()),
+		request.WithMethod(request.Get),
+		request.WithHost(endpoints.Get(endpoints.TMDB).Address()),
+		request.WithPath("3", "tv", fmt.Sprintf("%d", showID),
+			"season", fmt.Sprintf("%d", seasonNumber),
+			"episode", fmt.Sprintf("%d", episodeNumber)),
+		request.WithQueryArgs(map[string][]string{
+			"api_key": {apiKey},
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var episode Episode
+	_, err = req.Make(ctx, &episode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TV episode details: %w", err)
+	}
+
+	return &episode, nil
+}
@@ -38,6 +38,85 @@ func main() {
 		{session.Pending, "pending"},
 	}
 
+	var watchStatus = []struct {
+		Value  session.WatchStatus
+		TSName string
+	}{
+		{session.Watching, "watching"},
+		{session.Paused, "paused"},
+		{session.Finished, "finished"},
+	}
+
+	var backlogStatus = []struct {
+		Value  session.BacklogStatus
+		TSName string
+	}{
+		{session.Unplayed, "unplayed"},
+		{session.Playing, "playing"},
+		{session.Beaten, "beaten"},
+		{session.Abandoned, "abandoned"},
+	}
+
+	var musicUnit = []struct {
+		Value  session.MusicUnit
+		TSName string
+	}{
+		{session.TrackUnit, "track"},
+		{session.AlbumUnit, "album"},
+		{session.ArtistUnit, "artist"},
+	}
+
+	var mediaType = []struct {
+		Value  session.MediaType
+		TSName string
+	}{
+		{session.MusicMedia, "music"},
+		{session.MovieMedia, "movie"},
+		{session.BookMedia, "book"},
+		{session.TVMedia, "tv"},
+		{session.VideoGameMedia, "video_game"},
+	}
+
+	var company = []struct {
+		Value  session.Company
+		TSName string
+	}{
+		{session.Solo, "solo"},
+		{session.Partner, "partner"},
+		{session.Kids, "kids"},
+	}
+
+	var energyLevel = []struct {
+		Value  session.EnergyLevel
+		TSName string
+	}{
+		{session.LowEnergy, "low"},
+		{session.MediumEnergy, "medium"},
+		{session.HighEnergy, "high"},
+	}
+
+	var novelty = []struct {
+		Value  session.Novelty
+		TSName string
+	}{
+		{session.Familiar, "familiar"},
+		{session.Balanced, "balanced"},
+		{session.Adventurous, "adventurous"},
+	}
+
+	var authErrorCode = []struct {
+		Value  spotify.AuthErrorCode
+		TSName string
+	}{
+		{spotify.AuthTimeout, "timeout"},
+		{spotify.AuthDenied, "denied"},
+		{spotify.AuthStateMismatch, "state_mismatch"},
+		{spotify.AuthPortUnavailable, "port_unavailable"},
+		{spotify.AuthBrowserFailed, "browser_failed"},
+		{spotify.AuthExchangeFailed, "exchange_failed"},
+		{spotify.AuthCancelled, "cancelled"},
+	}
+
 	// binders map client to backend, see frontend/wailsjs/go/bindings
 	music := bindings.NewMusicBinder(ctx, cm, spotify.ClientID)
 	movies, mErr := bindings.NewMovieBinder(ctx, cm)
@@ -60,7 +139,16 @@ func main() {
 		log.Fatalf("Failed to create books binder: %v", bErr)
 	}
 
-	settings := &bindings.Settings{ContentManager: cm}
+	settings := &bindings.Settings{
+		ContentManager: cm,
+		Prefetchers: map[session.MediaType]bindings.Prefetching{
+			session.MusicMedia:     music,
+			session.MovieMedia:     movies,
+			session.TVMedia:        tvShows,
+			session.VideoGameMedia: games,
+			session.BookMedia:      books,
+		},
+	}
 
 	// Collect all LLM handlers for credential change registration
 	llmHandlers := []creds.LLMCredentialChangeHandler{
@@ -92,6 +180,8 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup: func(ctx context.Context) {
+			// Started first since onStartup may block on the Spotify auth flow
+			bindings.StartEpisodeAlerts(ctx, tvShows)
 			onStartup(ctx, llmHandlers, tmdbHandlers, rawgHandlers)
 		},
 		Bind: []interface{}{
@@ -105,6 +195,14 @@ func main() {
 		},
 		EnumBind: []interface{}{
 			suggestionOutcome,
+			watchStatus,
+			backlogStatus,
+			musicUnit,
+			mediaType,
+			company,
+			energyLevel,
+			novelty,
+			authErrorCode,
 		},
 	})
 
@@ -150,6 +248,7 @@ func onStartup(ctx context.Context,
 		log.Println("No valid authorization code found, starting authentication flow...")
 		if iErr := spotify.RunInitialAuthFlow(ctx); iErr != nil {
 			log.Printf("Authentication failed: %v", iErr)
+			bindings.ReportSpotifyAuthError(ctx, iErr)
 		} else {
 			log.Println("Authentication successful")
 		}